@@ -5,6 +5,7 @@ import (
 	"math/rand"
 
 	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/fractal"
 	"gonum.org/v1/plot"
 	"gonum.org/v1/plot/plotter"
 	"gonum.org/v1/plot/vg"
@@ -80,6 +81,57 @@ func main() {
 			log.Fatal(err)
 		}
 	}
+
+	{
+		// Create White noise generator with seed 100. It is a deterministic
+		// pseudo random noise which hashes the seed and the coordinates.
+		gen, err := noise.New(noise.White, seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		pathFile := "./2d_white.png"
+
+		if err := GenGraphImage(gen, "White Noise", pathFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		// Create hybrid multifractal of OpenSimplex noise with seed 100. The low
+		// areas are smooth and the high areas are rough.
+		src, err := noise.New(noise.OpenSimplex, seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		gen := fractal.New(src, seed)
+		gen.Mode = fractal.HybridMulti
+
+		pathFile := "./2d_hybridmulti.png"
+
+		if err := GenGraphImage(gen, "Hybrid Multifractal (OpenSimplex)", pathFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	{
+		// Create heterogeneous terrain of OpenSimplex noise with seed 100. The
+		// roughness varies with the altitude.
+		src, err := noise.New(noise.OpenSimplex, seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		gen := fractal.New(src, seed)
+		gen.Mode = fractal.HeteroTerrain
+
+		pathFile := "./2d_heteroterrain.png"
+
+		if err := GenGraphImage(gen, "Heterogeneous Terrain (OpenSimplex)", pathFile); err != nil {
+			log.Fatal(err)
+		}
+	}
 }
 
 // ----------------------------------------------------------------------------
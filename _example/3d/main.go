@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
 	"image/gif"
 	"log"
 	"os"
 
 	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
 	"github.com/pkg/errors"
 )
 
@@ -68,6 +70,21 @@ func main() {
 		fmt.Printf("\nOpenSimplex Noise image created.\n")
 	}
 
+	{
+		fmt.Println("Creating looping OpenSimplex noise image ...")
+
+		gen, err := noise.New(noise.OpenSimplex, seed)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if err := GenLoopNoiseImage(gen, "animation_opensimplex_loop.gif"); err != nil {
+			log.Fatal(err)
+		}
+
+		fmt.Printf("\nLooping OpenSimplex Noise image created.\n")
+	}
+
 	fmt.Printf("\nDone!\n")
 
 }
@@ -89,7 +106,7 @@ func CreatePaletteGray() color.Palette {
 
 // GenNoiseImage is the actual function which generates the image with the provided
 // noise generator.
-func GenNoiseImage(gen noise.Noise, pathFile string) error {
+func GenNoiseImage(gen noise.Generator, pathFile string) error {
 	// Define boundary of image
 	myBound := image.Rectangle{Max: image.Point{X: width, Y: height}}
 	// Create a color palette
@@ -99,26 +116,65 @@ func GenNoiseImage(gen noise.Noise, pathFile string) error {
 
 	// Z-axis is the frame number
 	for z := 0; z < depth; z++ {
-		zz := float64(z) / smoothness
 		canvas := image.NewPaletted(myBound, grayPalet)
 
+		// Create frame image of the noise at layer z
+		frame := noiseimage.NewGray(gen, myBound, noiseimage.Viewport{
+			Z:     float64(z) / smoothness,
+			Scale: 1. / zoomIn,
+			UseZ:  true,
+		})
+
+		draw.Draw(canvas, myBound, frame, image.Point{}, draw.Src)
+
+		fmt.Printf("Frame: %d\r", z+1)
+		outGif.Image = append(outGif.Image, canvas)
+		outGif.Delay = append(outGif.Delay, 0)
+	}
+
+	// Export image
+	if err := SaveImgGIF(outGif, pathFile); err != nil {
+		return errors.Wrap(err, "failed to save image")
+	}
+
+	return nil
+}
+
+// GenLoopNoiseImage generates the animation which loops seamlessly. The last
+// frame blends into the first one.
+func GenLoopNoiseImage(gen noise.Generator, pathFile string) error {
+	// Define boundary of image
+	myBound := image.Rectangle{Max: image.Point{X: width, Y: height}}
+	// Create a color palette
+	grayPalet := CreatePaletteGray()
+	// Create a new image
+	outGif := &gif.GIF{}
+
+	// Z-axis is the frame number. The time loops every depth frames.
+	g := &noise.Grid{
+		StepX: 1. / zoomIn, StepY: 1. / zoomIn, StepZ: 1. / smoothness,
+		Width: width, Height: height, Depth: depth,
+	}
+
+	frames := make([]float64, g.Len3D())
+
+	if err := noise.EvalLoopGrid3D64(gen, frames, g); err != nil {
+		return errors.Wrap(err, "failed to generate the frames")
+	}
+
+	for z := 0; z < depth; z++ {
+		canvas := image.NewPaletted(myBound, grayPalet)
+		frame := frames[z*width*height : (z+1)*width*height]
+
 		// Create frame image
 		for y := 0; y < height; y++ {
-			yy := float64(y) / zoomIn
-
 			for x := 0; x < width; x++ {
-				xx := float64(x) / zoomIn
-
-				// Generate noise at xx, yy of layer zz
-				grayC := NoiseToUint8(
-					gen.Eval64(xx, yy, zz),
-				)
+				grayC := NoiseToUint8(frame[y*width+x])
 
 				canvas.SetColorIndex(x, y, grayC) // Plot the pixel
 			}
 		}
 
-		fmt.Printf("Frame: %d\r", z+1)
 		outGif.Image = append(outGif.Image, canvas)
 		outGif.Delay = append(outGif.Delay, 0)
 	}
@@ -134,11 +190,11 @@ func GenNoiseImage(gen noise.Noise, pathFile string) error {
 // NoiseToUint8 converts the "in" value to uint8.
 //
 // The "in" is the noise value which is between -1 and 1 of float64. This function
-// converts it to a range of 0 to 255.
+// converts it to a range of 0 to 255 in the same manner as noiseimage.NewGray.
 func NoiseToUint8(in float64) uint8 {
-	out := ((1. - in) / 2.) * 255. // in: [-1, 1] --> out: [0, 255]
+	out := ((in + 1.) / 2.) * 255. // in: [-1, 1] --> out: [0, 255]
 
-	return uint8(out)
+	return uint8(out + .5)
 }
 
 func SaveImgGIF(img *gif.GIF, pathFile string) error {
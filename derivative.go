@@ -0,0 +1,143 @@
+package noise
+
+// The steps of the central finite difference to approximate the derivatives of
+// the generators without the analytical ones. The float32 one is larger to keep
+// the rounding error of float32 low, at the cost of the accuracy.
+const (
+	derivativeStep   = 1e-5
+	derivativeStep32 = 1. / 1024
+)
+
+// ----------------------------------------------------------------------------
+//  Types
+// ----------------------------------------------------------------------------
+
+// DerivativeGenerator is an optional interface of Generator which returns the
+// noise value with its partial derivatives computed analytically.
+//
+// The generators of Perlin and OpenSimplex implement this interface. Use the
+// EvalDeriv* functions to get the derivatives of any Generator, such as Custom.
+type DerivativeGenerator interface {
+	// EvalDeriv2D32 returns a float32 noise value at (x, y) and its partial
+	// derivatives.
+	EvalDeriv2D32(x, y float32) (v, dx, dy float32)
+	// EvalDeriv2D64 returns a float64 noise value at (x, y) and its partial
+	// derivatives.
+	EvalDeriv2D64(x, y float64) (v, dx, dy float64)
+	// EvalDeriv3D32 returns a float32 noise value at (x, y, z) and its partial
+	// derivatives.
+	EvalDeriv3D32(x, y, z float32) (v, dx, dy, dz float32)
+	// EvalDeriv3D64 returns a float64 noise value at (x, y, z) and its partial
+	// derivatives.
+	EvalDeriv3D64(x, y, z float64) (v, dx, dy, dz float64)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// EvalDeriv2D32 returns a float32 noise value of gen at (x, y) and its partial
+// derivatives along the x and y axes, such as for normal maps or flow fields.
+//
+// If gen implements DerivativeGenerator it uses the analytical derivatives.
+// Otherwise it approximates them by the central finite difference of
+// Eval2D32, so gen may support float32 only.
+func EvalDeriv2D32(gen Generator, x, y float32) (v, dx, dy float32) {
+	if native, ok := gen.(DerivativeGenerator); ok {
+		return native.EvalDeriv2D32(x, y)
+	}
+
+	return evalDeriv2D32(gen, x, y)
+}
+
+// EvalDeriv2D64 returns a float64 noise value of gen at (x, y) and its partial
+// derivatives along the x and y axes, such as for normal maps or flow fields.
+//
+// If gen implements DerivativeGenerator it uses the analytical derivatives.
+// Otherwise it approximates them by the central finite difference.
+func EvalDeriv2D64(gen Generator, x, y float64) (v, dx, dy float64) {
+	if native, ok := gen.(DerivativeGenerator); ok {
+		return native.EvalDeriv2D64(x, y)
+	}
+
+	return evalDeriv2D(gen, x, y)
+}
+
+// EvalDeriv3D32 returns a float32 noise value of gen at (x, y, z) and its
+// partial derivatives along the x, y and z axes.
+//
+// If gen implements DerivativeGenerator it uses the analytical derivatives.
+// Otherwise it approximates them by the central finite difference of
+// Eval3D32, so gen may support float32 only.
+func EvalDeriv3D32(gen Generator, x, y, z float32) (v, dx, dy, dz float32) {
+	if native, ok := gen.(DerivativeGenerator); ok {
+		return native.EvalDeriv3D32(x, y, z)
+	}
+
+	return evalDeriv3D32(gen, x, y, z)
+}
+
+// EvalDeriv3D64 returns a float64 noise value of gen at (x, y, z) and its
+// partial derivatives along the x, y and z axes.
+//
+// If gen implements DerivativeGenerator it uses the analytical derivatives.
+// Otherwise it approximates them by the central finite difference.
+func EvalDeriv3D64(gen Generator, x, y, z float64) (v, dx, dy, dz float64) {
+	if native, ok := gen.(DerivativeGenerator); ok {
+		return native.EvalDeriv3D64(x, y, z)
+	}
+
+	return evalDeriv3D(gen, x, y, z)
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// evalDeriv2D returns the value of gen at (x, y) and its derivatives by the
+// central finite difference.
+func evalDeriv2D(gen Generator, x, y float64) (v, dx, dy float64) {
+	const h = derivativeStep
+
+	v = Eval2D64(gen, x, y)
+	dx = (Eval2D64(gen, x+h, y) - Eval2D64(gen, x-h, y)) / (2 * h)
+	dy = (Eval2D64(gen, x, y+h) - Eval2D64(gen, x, y-h)) / (2 * h)
+
+	return v, dx, dy
+}
+
+// evalDeriv2D32 is the float32 version of evalDeriv2D.
+func evalDeriv2D32(gen Generator, x, y float32) (v, dx, dy float32) {
+	const h = derivativeStep32
+
+	v = Eval2D32(gen, x, y)
+	dx = (Eval2D32(gen, x+h, y) - Eval2D32(gen, x-h, y)) / (2 * h)
+	dy = (Eval2D32(gen, x, y+h) - Eval2D32(gen, x, y-h)) / (2 * h)
+
+	return v, dx, dy
+}
+
+// evalDeriv3D returns the value of gen at (x, y, z) and its derivatives by the
+// central finite difference.
+func evalDeriv3D(gen Generator, x, y, z float64) (v, dx, dy, dz float64) {
+	const h = derivativeStep
+
+	v = Eval3D64(gen, x, y, z)
+	dx = (Eval3D64(gen, x+h, y, z) - Eval3D64(gen, x-h, y, z)) / (2 * h)
+	dy = (Eval3D64(gen, x, y+h, z) - Eval3D64(gen, x, y-h, z)) / (2 * h)
+	dz = (Eval3D64(gen, x, y, z+h) - Eval3D64(gen, x, y, z-h)) / (2 * h)
+
+	return v, dx, dy, dz
+}
+
+// evalDeriv3D32 is the float32 version of evalDeriv3D.
+func evalDeriv3D32(gen Generator, x, y, z float32) (v, dx, dy, dz float32) {
+	const h = derivativeStep32
+
+	v = Eval3D32(gen, x, y, z)
+	dx = (Eval3D32(gen, x+h, y, z) - Eval3D32(gen, x-h, y, z)) / (2 * h)
+	dy = (Eval3D32(gen, x, y+h, z) - Eval3D32(gen, x, y-h, z)) / (2 * h)
+	dz = (Eval3D32(gen, x, y, z+h) - Eval3D32(gen, x, y, z-h)) / (2 * h)
+
+	return v, dx, dy, dz
+}
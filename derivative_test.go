@@ -0,0 +1,132 @@
+package noise_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalDeriv_analytic_equals_to_numeric(t *testing.T) {
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		_, ok := gen.(noise.DerivativeGenerator)
+		require.True(t, ok, "algo %d should implement DerivativeGenerator", algo)
+
+		// Without the native method, it falls back to the finite difference.
+		fallback := evalOnly{Generator: gen}
+
+		for i := 0.; i < 50; i++ {
+			x, y, z := i*0.173-3.1, i*0.0917+0.05, i*0.061+0.02
+
+			v, dx, dy := noise.EvalDeriv2D64(gen, x, y)
+			fv, fdx, fdy := noise.EvalDeriv2D64(fallback, x, y)
+
+			require.Equal(t, fv, v, "algo %d: the value should be the same at (%v, %v)", algo, x, y)
+			require.InDelta(t, fdx, dx, 1e-6, "algo %d: dx at (%v, %v)", algo, x, y)
+			require.InDelta(t, fdy, dy, 1e-6, "algo %d: dy at (%v, %v)", algo, x, y)
+
+			v, dx, dy, dz := noise.EvalDeriv3D64(gen, x, y, z)
+			fv, fdx, fdy, fdz := noise.EvalDeriv3D64(fallback, x, y, z)
+
+			require.Equal(t, fv, v, "algo %d: the value should be the same at (%v, %v, %v)", algo, x, y, z)
+			require.InDelta(t, fdx, dx, 1e-6, "algo %d: dx at (%v, %v, %v)", algo, x, y, z)
+			require.InDelta(t, fdy, dy, 1e-6, "algo %d: dy at (%v, %v, %v)", algo, x, y, z)
+			require.InDelta(t, fdz, dz, 1e-6, "algo %d: dz at (%v, %v, %v)", algo, x, y, z)
+		}
+	}
+}
+
+func TestEvalDeriv_custom(t *testing.T) {
+	gen, err := noise.New(noise.Custom, 100)
+	require.NoError(t, err)
+
+	// f = sin(x) * cos(y) * cos(z) / 2
+	require.NoError(t, gen.SetEval64(func(seed int64, dim ...float64) float64 {
+		v := math.Sin(dim[0]) * math.Cos(dim[1]) / 2
+		if len(dim) > 2 {
+			v *= math.Cos(dim[2])
+		}
+
+		return v
+	}))
+
+	for i := 0.; i < 20; i++ {
+		x, y, z := i*0.31-3, i*0.17+0.5, 1-i*0.23
+
+		v, dx, dy := noise.EvalDeriv2D64(gen, x, y)
+
+		require.Equal(t, math.Sin(x)*math.Cos(y)/2, v)
+		require.InDelta(t, math.Cos(x)*math.Cos(y)/2, dx, 1e-9)
+		require.InDelta(t, -math.Sin(x)*math.Sin(y)/2, dy, 1e-9)
+
+		v, dx, dy, dz := noise.EvalDeriv3D64(gen, x, y, z)
+
+		require.Equal(t, math.Sin(x)*math.Cos(y)/2*math.Cos(z), v)
+		require.InDelta(t, math.Cos(x)*math.Cos(y)/2*math.Cos(z), dx, 1e-9)
+		require.InDelta(t, -math.Sin(x)*math.Sin(y)/2*math.Cos(z), dy, 1e-9)
+		require.InDelta(t, -math.Sin(x)*math.Cos(y)/2*math.Sin(z), dz, 1e-9)
+	}
+}
+
+func TestEvalDeriv32(t *testing.T) {
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		// The finite difference in float32 is less accurate than the analytic one.
+		for g, delta := range map[noise.Generator]float64{gen: 1e-6, evalOnly{Generator: gen}: 1e-2} {
+			v64, dx64, dy64 := noise.EvalDeriv2D64(g, 0.25, 0.5)
+			v32, dx32, dy32 := noise.EvalDeriv2D32(g, 0.25, 0.5)
+
+			require.InDelta(t, v64, float64(v32), 1e-6)
+			require.InDelta(t, dx64, float64(dx32), delta)
+			require.InDelta(t, dy64, float64(dy32), delta)
+
+			v64, dx64, dy64, dz64 := noise.EvalDeriv3D64(g, 0.25, 0.5, 0.125)
+			v32, dx32, dy32, dz32 := noise.EvalDeriv3D32(g, 0.25, 0.5, 0.125)
+
+			require.InDelta(t, v64, float64(v32), 1e-6)
+			require.InDelta(t, dx64, float64(dx32), delta)
+			require.InDelta(t, dy64, float64(dy32), delta)
+			require.InDelta(t, dz64, float64(dz32), delta)
+		}
+	}
+}
+
+func TestEvalDeriv32_custom_float32_only(t *testing.T) {
+	gen, err := noise.New(noise.Custom, 100)
+	require.NoError(t, err)
+
+	// f = sin(x) * cos(y) * cos(z) / 2, without the float64 function.
+	require.NoError(t, gen.SetEval32(func(seed int64, dim ...float32) float32 {
+		v := math.Sin(float64(dim[0])) * math.Cos(float64(dim[1])) / 2
+		if len(dim) > 2 {
+			v *= math.Cos(float64(dim[2]))
+		}
+
+		return float32(v)
+	}))
+
+	for i := 0.; i < 20; i++ {
+		x, y, z := i*0.31-3, i*0.17+0.5, 1-i*0.23
+
+		require.NotPanics(t, func() {
+			_, dx, dy := noise.EvalDeriv2D32(gen, float32(x), float32(y))
+
+			require.InDelta(t, math.Cos(x)*math.Cos(y)/2, float64(dx), 1e-3)
+			require.InDelta(t, -math.Sin(x)*math.Sin(y)/2, float64(dy), 1e-3)
+		})
+
+		require.NotPanics(t, func() {
+			_, dx, dy, dz := noise.EvalDeriv3D32(gen, float32(x), float32(y), float32(z))
+
+			require.InDelta(t, math.Cos(x)*math.Cos(y)/2*math.Cos(z), float64(dx), 1e-3)
+			require.InDelta(t, -math.Sin(x)*math.Sin(y)/2*math.Cos(z), float64(dy), 1e-3)
+			require.InDelta(t, -math.Sin(x)*math.Cos(y)/2*math.Sin(z), float64(dz), 1e-3)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package noise
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// Eval1D32 returns a float32 noise value of gen at the 1-dimensional coordinates.
+//
+// If gen implements Generator1D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval32(x).
+func Eval1D32(gen Generator, x float32) float32 {
+	if g, ok := gen.(Generator1D); ok {
+		return g.Eval1D32(x)
+	}
+
+	return gen.Eval32(x)
+}
+
+// Eval2D32 returns a float32 noise value of gen at the 2-dimensional coordinates.
+//
+// If gen implements Generator2D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval32(x, y).
+func Eval2D32(gen Generator, x, y float32) float32 {
+	if g, ok := gen.(Generator2D); ok {
+		return g.Eval2D32(x, y)
+	}
+
+	return gen.Eval32(x, y)
+}
+
+// Eval3D32 returns a float32 noise value of gen at the 3-dimensional coordinates.
+//
+// If gen implements Generator3D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval32(x, y, z).
+func Eval3D32(gen Generator, x, y, z float32) float32 {
+	if g, ok := gen.(Generator3D); ok {
+		return g.Eval3D32(x, y, z)
+	}
+
+	return gen.Eval32(x, y, z)
+}
+
+// Eval4D32 returns a float32 noise value of gen at the 4-dimensional coordinates.
+//
+// If gen implements Generator4D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval32(x, y, z, w).
+func Eval4D32(gen Generator, x, y, z, w float32) float32 {
+	if g, ok := gen.(Generator4D); ok {
+		return g.Eval4D32(x, y, z, w)
+	}
+
+	return gen.Eval32(x, y, z, w)
+}
+
+// Eval1D64 returns a float64 noise value of gen at the 1-dimensional coordinates.
+//
+// If gen implements Generator1D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval64(x).
+func Eval1D64(gen Generator, x float64) float64 {
+	if g, ok := gen.(Generator1D); ok {
+		return g.Eval1D64(x)
+	}
+
+	return gen.Eval64(x)
+}
+
+// Eval2D64 returns a float64 noise value of gen at the 2-dimensional coordinates.
+//
+// If gen implements Generator2D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval64(x, y).
+func Eval2D64(gen Generator, x, y float64) float64 {
+	if g, ok := gen.(Generator2D); ok {
+		return g.Eval2D64(x, y)
+	}
+
+	return gen.Eval64(x, y)
+}
+
+// Eval3D64 returns a float64 noise value of gen at the 3-dimensional coordinates.
+//
+// If gen implements Generator3D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval64(x, y, z).
+func Eval3D64(gen Generator, x, y, z float64) float64 {
+	if g, ok := gen.(Generator3D); ok {
+		return g.Eval3D64(x, y, z)
+	}
+
+	return gen.Eval64(x, y, z)
+}
+
+// Eval4D64 returns a float64 noise value of gen at the 4-dimensional coordinates.
+//
+// If gen implements Generator4D it calls the dimension-specific method without
+// allocation. Otherwise it calls gen.Eval64(x, y, z, w).
+func Eval4D64(gen Generator, x, y, z, w float64) float64 {
+	if g, ok := gen.(Generator4D); ok {
+		return g.Eval4D64(x, y, z, w)
+	}
+
+	return gen.Eval64(x, y, z, w)
+}
@@ -0,0 +1,42 @@
+package noise_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalND_fallback_equals_to_native(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	fallback := evalOnly{Generator: gen}
+
+	for _, g := range []noise.Generator{gen, fallback} {
+		require.Equal(t, gen.Eval32(0.1), noise.Eval1D32(g, 0.1))
+		require.Equal(t, gen.Eval32(0.1, 0.2), noise.Eval2D32(g, 0.1, 0.2))
+		require.Equal(t, gen.Eval32(0.1, 0.2, 0.3), noise.Eval3D32(g, 0.1, 0.2, 0.3))
+		require.Equal(t, gen.Eval32(0.1, 0.2, 0.3, 0.4), noise.Eval4D32(g, 0.1, 0.2, 0.3, 0.4))
+		require.Equal(t, gen.Eval64(0.1), noise.Eval1D64(g, 0.1))
+		require.Equal(t, gen.Eval64(0.1, 0.2), noise.Eval2D64(g, 0.1, 0.2))
+		require.Equal(t, gen.Eval64(0.1, 0.2, 0.3), noise.Eval3D64(g, 0.1, 0.2, 0.3))
+		require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), noise.Eval4D64(g, 0.1, 0.2, 0.3, 0.4))
+	}
+}
+
+func TestEvalND_zero_allocation(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = noise.Eval1D64(gen, 0.1)
+		_ = noise.Eval2D64(gen, 0.1, 0.2)
+		_ = noise.Eval3D64(gen, 0.1, 0.2, 0.3)
+		_ = noise.Eval4D64(gen, 0.1, 0.2, 0.3, 0.4)
+		_ = noise.Eval1D32(gen, 0.1)
+		_ = noise.Eval2D32(gen, 0.1, 0.2)
+		_ = noise.Eval3D32(gen, 0.1, 0.2, 0.3)
+		_ = noise.Eval4D32(gen, 0.1, 0.2, 0.3, 0.4)
+	}))
+}
@@ -1,6 +1,7 @@
 package noise_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"math/rand"
@@ -31,6 +32,145 @@ func ExampleNew() {
 	}
 }
 
+func ExampleEvalGrid2D64() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 3x2 sample points from (0, 0) with the interval of 0.1.
+	g := &noise.Grid{
+		StepX: 0.1, StepY: 0.1,
+		Width: 3, Height: 2,
+	}
+
+	heightmap := make([]float64, g.Len2D())
+
+	if err := noise.EvalGrid2D64(gen, heightmap, g); err != nil {
+		log.Fatal(err)
+	}
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			fmt.Printf("%d;%d;%0.4f\n", x, y, heightmap[y*g.Width+x])
+		}
+	}
+
+	// Output:
+	// 0;0;0.0000
+	// 1;0;0.1664
+	// 2;0;0.3092
+	// 0;1;-0.0673
+	// 1;1;0.0950
+	// 2;1;0.2317
+}
+
+func ExampleEvalTileGrid2D64() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 4x2 sample points which repeat every 4 points along the x axis and
+	// every 2 points along the y axis.
+	g := &noise.Grid{
+		StepX: 0.5, StepY: 0.5,
+		Width: 4, Height: 2,
+	}
+
+	tile := make([]float64, g.Len2D())
+
+	if err := noise.EvalTileGrid2D64(gen, tile, g); err != nil {
+		log.Fatal(err)
+	}
+
+	for y := 0; y < g.Height; y++ {
+		// The first point of the next tile is the same as the first column.
+		next := noise.EvalTile2D64(gen, g.AtX(g.Width), g.AtY(y), 2, 1)
+
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f;%0.4f\n",
+			tile[y*g.Width], tile[y*g.Width+1], tile[y*g.Width+2], tile[y*g.Width+3], next)
+	}
+
+	// Output:
+	// 0.2449;0.2452;-0.0603;-0.0737;0.2449
+	// 0.0607;0.0997;-0.2224;-0.1966;0.0607
+}
+
+func ExampleEvalDeriv2D64() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for x := 0.; x < 3; x++ {
+		v, dx, dy := noise.EvalDeriv2D64(gen, x/10, 0.5)
+
+		fmt.Printf("%0.4f;%0.4f;%0.4f\n", v, dx, dy)
+	}
+
+	// Output:
+	// -0.3263;0.2256;-0.6130
+	// -0.2887;0.4833;-1.0447
+	// -0.2396;0.4465;-1.3372
+}
+
+func ExampleEvalGrid3D64Parallel() {
+	const seed = 100
+
+	gen, err := noise.New(noise.Perlin, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 100x100 pixels of 10 frames. Z-axis is the frame number.
+	g := &noise.Grid{
+		StepX: 1. / 25, StepY: 1. / 25, StepZ: 1. / 5,
+		Width: 100, Height: 100, Depth: 10,
+	}
+
+	frames := make([]float64, g.Len3D())
+
+	// Use 4 goroutines. Set 0 to use runtime.GOMAXPROCS(0) goroutines.
+	if err := noise.EvalGrid3D64Parallel(context.Background(), gen, frames, g, 4); err != nil {
+		log.Fatal(err)
+	}
+
+	// Value at (x, y, z) = (1, 2, 3)
+	fmt.Printf("%0.4f\n", frames[(3*g.Height+2)*g.Width+1])
+
+	// Output: 0.3660
+}
+
+func ExampleEval64E() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// OpenSimplex returns exactly 0 at the origin. Which is a legitimate value.
+	v, err := noise.Eval64E(gen, 0, 0)
+
+	fmt.Printf("%0.4f, %v\n", v, err)
+
+	// Unsupported number of dimensions is an error. Eval64 returns a silent 0.
+	_, err = noise.Eval64E(gen, 0, 0, 0, 0, 0)
+
+	fmt.Println(err)
+
+	// Output:
+	// 0.0000, <nil>
+	// unsupported number of dimensions: 5. OpenSimplex supports 1 to 4 dimensions
+}
+
 // ----------------------------------------------------------------------------
 //  Custom Noise (User-Defind Function)
 // ----------------------------------------------------------------------------
@@ -0,0 +1,146 @@
+package noise
+
+import (
+	"github.com/KEINOS/go-noise/pkg/grid"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Types
+// ----------------------------------------------------------------------------
+
+// Grid holds the origin, the step and the size of a regular lattice of sample
+// points to evaluate in a batch. See the grid package for the details.
+type Grid = grid.Grid
+
+// GridGenerator is an optional interface of Generator which evaluates a grid of
+// sample points natively in a batch.
+//
+// The generators of Perlin, OpenSimplex and Custom implement this interface.
+// Use the EvalGrid* functions to evaluate a grid with any Generator.
+type GridGenerator interface {
+	// EvalGrid2D32 fills dst with the float32 noise values of the 2D grid.
+	EvalGrid2D32(dst []float32, g *Grid) error
+	// EvalGrid2D64 fills dst with the float64 noise values of the 2D grid.
+	EvalGrid2D64(dst []float64, g *Grid) error
+	// EvalGrid3D32 fills dst with the float32 noise values of the 3D grid.
+	EvalGrid3D32(dst []float32, g *Grid) error
+	// EvalGrid3D64 fills dst with the float64 noise values of the 3D grid.
+	EvalGrid3D64(dst []float64, g *Grid) error
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// EvalGrid2D32 fills dst with the float32 noise values of gen at the sample
+// points of the 2D grid in row-major order.
+//
+// If gen implements GridGenerator it uses the native batch evaluation. Otherwise
+// it calls gen.Eval32(x, y) for each sample point.
+func EvalGrid2D32(gen Generator, dst []float32, g *Grid) error {
+	if native, ok := gen.(GridGenerator); ok {
+		return native.EvalGrid2D32(dst, g)
+	}
+
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	for y := 0; y < g.Height; y++ {
+		yy := float32(g.AtY(y))
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = gen.Eval32(float32(g.AtX(x)), yy)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid2D64 fills dst with the float64 noise values of gen at the sample
+// points of the 2D grid in row-major order.
+//
+// If gen implements GridGenerator it uses the native batch evaluation. Otherwise
+// it calls gen.Eval64(x, y) for each sample point.
+func EvalGrid2D64(gen Generator, dst []float64, g *Grid) error {
+	if native, ok := gen.(GridGenerator); ok {
+		return native.EvalGrid2D64(dst, g)
+	}
+
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	for y := 0; y < g.Height; y++ {
+		yy := g.AtY(y)
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = gen.Eval64(g.AtX(x), yy)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D32 fills dst with the float32 noise values of gen at the sample
+// points of the 3D grid in row-major order.
+//
+// If gen implements GridGenerator it uses the native batch evaluation. Otherwise
+// it calls gen.Eval32(x, y, z) for each sample point.
+func EvalGrid3D32(gen Generator, dst []float32, g *Grid) error {
+	if native, ok := gen.(GridGenerator); ok {
+		return native.EvalGrid3D32(dst, g)
+	}
+
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	for z := 0; z < g.Depth; z++ {
+		zz := float32(g.AtZ(z))
+
+		for y := 0; y < g.Height; y++ {
+			yy := float32(g.AtY(y))
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = gen.Eval32(float32(g.AtX(x)), yy, zz)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D64 fills dst with the float64 noise values of gen at the sample
+// points of the 3D grid in row-major order.
+//
+// If gen implements GridGenerator it uses the native batch evaluation. Otherwise
+// it calls gen.Eval64(x, y, z) for each sample point.
+func EvalGrid3D64(gen Generator, dst []float64, g *Grid) error {
+	if native, ok := gen.(GridGenerator); ok {
+		return native.EvalGrid3D64(dst, g)
+	}
+
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	for z := 0; z < g.Depth; z++ {
+		zz := g.AtZ(z)
+
+		for y := 0; y < g.Height; y++ {
+			yy := g.AtY(y)
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = gen.Eval64(g.AtX(x), yy, zz)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package noise_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+// evalOnly hides the native batch evaluation of the embedded generator.
+type evalOnly struct {
+	noise.Generator
+}
+
+func TestEvalGrid_fallback_equals_to_native(t *testing.T) {
+	g := &noise.Grid{
+		X: -0.5, Y: 0.25, Z: 1,
+		StepX: 0.1, StepY: 0.07, StepZ: 0.2,
+		Width: 5, Height: 4, Depth: 3,
+	}
+
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		_, ok := gen.(noise.GridGenerator)
+		require.True(t, ok, "built-in generators should implement GridGenerator")
+
+		fallback := evalOnly{Generator: gen}
+
+		native2D32, plain2D32 := make([]float32, g.Len2D()), make([]float32, g.Len2D())
+		native2D64, plain2D64 := make([]float64, g.Len2D()), make([]float64, g.Len2D())
+		native3D32, plain3D32 := make([]float32, g.Len3D()), make([]float32, g.Len3D())
+		native3D64, plain3D64 := make([]float64, g.Len3D()), make([]float64, g.Len3D())
+
+		require.NoError(t, noise.EvalGrid2D32(gen, native2D32, g))
+		require.NoError(t, noise.EvalGrid2D32(fallback, plain2D32, g))
+		require.NoError(t, noise.EvalGrid2D64(gen, native2D64, g))
+		require.NoError(t, noise.EvalGrid2D64(fallback, plain2D64, g))
+		require.NoError(t, noise.EvalGrid3D32(gen, native3D32, g))
+		require.NoError(t, noise.EvalGrid3D32(fallback, plain3D32, g))
+		require.NoError(t, noise.EvalGrid3D64(gen, native3D64, g))
+		require.NoError(t, noise.EvalGrid3D64(fallback, plain3D64, g))
+
+		require.Equal(t, native2D32, plain2D32)
+		require.Equal(t, native2D64, plain2D64)
+		require.Equal(t, native3D32, plain3D32)
+		require.Equal(t, native3D64, plain3D64)
+	}
+}
+
+func TestEvalGrid_fallback_short_destination(t *testing.T) {
+	gen, err := noise.New(noise.Perlin, 100)
+	require.NoError(t, err)
+
+	fallback := evalOnly{Generator: gen}
+	g := &noise.Grid{Width: 2, Height: 2, Depth: 2}
+
+	require.Error(t, noise.EvalGrid2D32(fallback, nil, g))
+	require.Error(t, noise.EvalGrid2D64(fallback, nil, g))
+	require.Error(t, noise.EvalGrid3D32(fallback, nil, g))
+	require.Error(t, noise.EvalGrid3D64(fallback, nil, g))
+}
@@ -0,0 +1,19 @@
+/*
+Package displace provides the values shared by the generators which displace
+the sample point by noise, such as the turbulence module and domain warping.
+*/
+package displace
+
+// Offsets are the shifts of the sample point for each axis of the displacement,
+// so that each axis is displaced by a different part of the same noise. The
+// offsets of the first axis are 0 and the others are away enough not to be
+// correlated.
+//
+// Offsets[axis][i] is the shift of the i-th coordinate to sample the
+// displacement of the axis.
+var Offsets = [4][4]float64{
+	{0, 0, 0, 0},
+	{5.2, 1.3, 2.8, 7.1},
+	{1.7, 9.2, 8.3, 3.6},
+	{8.9, 4.4, 6.1, 2.5},
+}
@@ -0,0 +1,45 @@
+/*
+Package hash provides the integer hashing shared by the noise generators which
+derive their random values from the seed and the coordinates.
+*/
+package hash
+
+// Primes are the odd constants to spread the bits of each axis.
+var Primes = [4]uint64{0x9E3779B97F4A7C15, 0xC2B2AE3D27D4EB4F, 0x165667B19E3779F9, 0xD6E8FEB86659FD93}
+
+// ----------------------------------------------------------------------------
+//  Functions (Public)
+// ----------------------------------------------------------------------------
+
+// Lattice returns the hash of the seed and the first numDim coordinates of the
+// lattice point.
+func Lattice(seed int64, numDim int, point [4]int64) uint64 {
+	hash := Mix(uint64(seed))
+
+	for i := 0; i < numDim; i++ {
+		hash = Mix(hash ^ uint64(point[i])*Primes[i])
+	}
+
+	return hash
+}
+
+// Mix returns the well distributed bits of x. It is the finalizer of SplitMix64.
+func Mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xBF58476D1CE4E5B9
+	x ^= x >> 27
+	x *= 0x94D049BB133111EB
+	x ^= x >> 31
+
+	return x
+}
+
+// ToRange returns the upper 53 bits of x as a float64 in the range of [-1, 1].
+func ToRange(x uint64) float64 {
+	return float64(x>>11)/(1<<53-1)*2 - 1
+}
+
+// ToUnit returns the upper 53 bits of x as a float64 in the range of [0, 1).
+func ToUnit(x uint64) float64 {
+	return float64(x>>11) / (1 << 53)
+}
@@ -0,0 +1,35 @@
+package hash_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise/internal/hash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMix(t *testing.T) {
+	// The finalizer of SplitMix64 maps 0 to 0 and spreads a single bit.
+	require.Equal(t, uint64(0), hash.Mix(0))
+	require.Equal(t, uint64(0x5692161D100B05E5), hash.Mix(1))
+}
+
+func TestLattice(t *testing.T) {
+	point := [4]int64{1, 2, 3, 4}
+
+	require.Equal(t, hash.Lattice(100, 2, point), hash.Lattice(100, 2, [4]int64{1, 2, 5, 6}),
+		"the coordinates over the number of dimensions should be ignored")
+	require.NotEqual(t, hash.Lattice(100, 2, point), hash.Lattice(101, 2, point))
+	require.NotEqual(t, hash.Lattice(100, 2, point), hash.Lattice(100, 2, [4]int64{2, 1}),
+		"each axis should be hashed differently")
+}
+
+func TestToRange(t *testing.T) {
+	require.Equal(t, -1., hash.ToRange(0))
+	require.Equal(t, 1., hash.ToRange(math.MaxUint64))
+}
+
+func TestToUnit(t *testing.T) {
+	require.Equal(t, 0., hash.ToUnit(0))
+	require.Less(t, hash.ToUnit(math.MaxUint64), 1.)
+}
@@ -0,0 +1,28 @@
+/*
+Package testgen provides the generators shared by the tests of the packages
+which take other generators as the source.
+*/
+package testgen
+
+// ----------------------------------------------------------------------------
+//  Type: Constant
+// ----------------------------------------------------------------------------
+
+// Constant is a source which returns the same value everywhere.
+type Constant float64
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns the value as float32.
+func (c Constant) Eval32(dim ...float32) float32 { return float32(c) }
+
+// Eval64 returns the value as float64.
+func (c Constant) Eval64(dim ...float64) float64 { return float64(c) }
+
+// SetEval32 does nothing.
+func (c Constant) SetEval32(f func(seed int64, dim ...float32) float32) error { return nil }
+
+// SetEval64 does nothing.
+func (c Constant) SetEval64(f func(seed int64, dim ...float64) float64) error { return nil }
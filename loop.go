@@ -0,0 +1,119 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// EvalLoop3D32 returns a float32 noise value of gen at (x, y) of the time t which
+// repeats every period of time. In other words, the value at (x, y, t+period) is
+// the same as (x, y, t), so the animation loops seamlessly.
+//
+// See EvalLoop3D64 for the details.
+func EvalLoop3D32(gen Generator, x, y, t, period float32) float32 {
+	return float32(evalLoop(gen, float64(x), float64(y), float64(t), float64(period)))
+}
+
+// EvalLoop3D64 returns a float64 noise value of gen at (x, y) of the time t which
+// repeats every period of time. In other words, the value at (x, y, t+period) is
+// the same as (x, y, t), so the animation loops seamlessly.
+//
+// It maps the time onto a circle in the extra dimensions, so gen must support
+// 4D. The circumference of the circle is the period, so the pattern changes as
+// fast as Eval64(x, y, t) does. It returns 0 if period is not positive.
+func EvalLoop3D64(gen Generator, x, y, t, period float64) float64 {
+	return evalLoop(gen, x, y, t, period)
+}
+
+// EvalLoopGrid3D32 fills dst with the float32 noise values of gen at the sample
+// points of the 3D grid in row-major order. The Z axis of the grid is the time,
+// and each Width*Height slice of dst is a frame of the animation. The Depth is
+// the number of the frames and the last frame loops back to the first.
+//
+// The period is Depth*StepZ. See EvalLoop3D32 for the details.
+func EvalLoopGrid3D32(gen Generator, dst []float32, g *Grid) error {
+	if err := validateLoop(g, len(dst)); err != nil {
+		return err
+	}
+
+	period := float32(float64(g.Depth) * g.StepZ)
+
+	for z := 0; z < g.Depth; z++ {
+		zz := float32(g.AtZ(z))
+
+		for y := 0; y < g.Height; y++ {
+			yy := float32(g.AtY(y))
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = EvalLoop3D32(gen, float32(g.AtX(x)), yy, zz, period)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvalLoopGrid3D64 fills dst with the float64 noise values of gen at the sample
+// points of the 3D grid in row-major order. The Z axis of the grid is the time,
+// and each Width*Height slice of dst is a frame of the animation. The Depth is
+// the number of the frames and the last frame loops back to the first.
+//
+// The period is Depth*StepZ. See EvalLoop3D64 for the details.
+func EvalLoopGrid3D64(gen Generator, dst []float64, g *Grid) error {
+	if err := validateLoop(g, len(dst)); err != nil {
+		return err
+	}
+
+	period := float64(g.Depth) * g.StepZ
+
+	for z := 0; z < g.Depth; z++ {
+		zz := g.AtZ(z)
+
+		for y := 0; y < g.Height; y++ {
+			yy := g.AtY(y)
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = EvalLoop3D64(gen, g.AtX(x), yy, zz, period)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// evalLoop returns the value of gen at (x, y) on the circle of the time t.
+func evalLoop(gen Generator, x, y, t, period float64) float64 {
+	if period <= 0 {
+		return 0
+	}
+
+	sinT, cosT := math.Sincos(2 * math.Pi * wrap(t, period) / period)
+	radius := period / (2 * math.Pi)
+
+	return Eval4D64(gen, x, y, radius*cosT, radius*sinT)
+}
+
+// validateLoop returns an error if dst is too short for the grid or the period
+// of the grid is not positive.
+func validateLoop(g *Grid, lenDst int) error {
+	if err := g.Validate3D(lenDst); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if g.StepZ <= 0 {
+		return errors.Errorf("invalid grid: the step of Z must be positive to loop. StepZ: %v", g.StepZ)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package noise_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalLoop3D_repeats(t *testing.T) {
+	const period = 5.
+
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex, noise.Value} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		for i := 0.; i <= 40; i++ {
+			// Dyadic fractions keep the shifts by the period exact.
+			x, y, tt := i/8, 1-i/16, i/8
+
+			require.Equal(t, noise.EvalLoop3D64(gen, x, y, tt, period), noise.EvalLoop3D64(gen, x, y, tt+period, period),
+				"algo %d: it should repeat at t=%v", algo, tt)
+			require.Equal(t, noise.EvalLoop3D64(gen, x, y, tt, period), noise.EvalLoop3D64(gen, x, y, tt-3*period, period),
+				"algo %d: it should repeat at t=%v", algo, tt)
+			require.Equal(t, noise.EvalLoop3D32(gen, float32(x), float32(y), 0, period), noise.EvalLoop3D32(gen, float32(x), float32(y), period, period),
+				"algo %d: it should repeat at t=0", algo)
+		}
+
+		require.NotEqual(t, noise.EvalLoop3D64(gen, 0.3, 0.7, 0, period), noise.EvalLoop3D64(gen, 0.3, 0.7, period/2, period),
+			"algo %d: it should change over time", algo)
+	}
+}
+
+func TestEvalLoop3D_non_positive_period(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	require.Zero(t, noise.EvalLoop3D64(gen, 0.5, 0.5, 0.5, 0))
+	require.Zero(t, noise.EvalLoop3D64(gen, 0.5, 0.5, 0.5, -1))
+	require.Zero(t, noise.EvalLoop3D32(gen, 0.5, 0.5, 0.5, 0))
+}
+
+func TestEvalLoopGrid3D(t *testing.T) {
+	g := &noise.Grid{
+		X: -0.5, Y: 0.25,
+		StepX: 0.1, StepY: 0.1, StepZ: 0.2,
+		Width: 5, Height: 4, Depth: 30,
+	}
+
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	out32 := make([]float32, g.Len3D())
+	out64 := make([]float64, g.Len3D())
+
+	require.NoError(t, noise.EvalLoopGrid3D32(gen, out32, g))
+	require.NoError(t, noise.EvalLoopGrid3D64(gen, out64, g))
+
+	period := float64(g.Depth) * g.StepZ
+	frameSize := g.Len2D()
+
+	for z := 0; z < g.Depth; z++ {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				i := z*frameSize + y*g.Width + x
+
+				require.Equal(t, noise.EvalLoop3D64(gen, g.AtX(x), g.AtY(y), g.AtZ(z), period), out64[i])
+				require.InDelta(t, out64[i], float64(out32[i]), 1e-6)
+			}
+		}
+	}
+
+	// The last frame blends into the first one as smoothly as the others.
+	maxDiff := func(a, b []float64) float64 {
+		diff := 0.
+
+		for i := range a {
+			diff = math.Max(diff, math.Abs(a[i]-b[i]))
+		}
+
+		return diff
+	}
+
+	var maxStep float64
+
+	for z := 1; z < g.Depth; z++ {
+		maxStep = math.Max(maxStep, maxDiff(out64[(z-1)*frameSize:z*frameSize], out64[z*frameSize:(z+1)*frameSize]))
+	}
+
+	last := out64[(g.Depth-1)*frameSize:]
+	first := out64[:frameSize]
+
+	require.LessOrEqual(t, maxDiff(last, first), maxStep*1.5, "the loop should be seamless")
+}
+
+func TestEvalLoopGrid3D_invalid_grid(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	g := &noise.Grid{StepZ: 0.1, Width: 2, Height: 2, Depth: 2}
+
+	require.Error(t, noise.EvalLoopGrid3D32(gen, make([]float32, g.Len2D()), g))
+	require.Error(t, noise.EvalLoopGrid3D64(gen, make([]float64, g.Len2D()), g))
+
+	g.StepZ = 0
+
+	require.Error(t, noise.EvalLoopGrid3D32(gen, make([]float32, g.Len3D()), g))
+	require.Error(t, noise.EvalLoopGrid3D64(gen, make([]float64, g.Len3D()), g))
+}
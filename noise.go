@@ -2,8 +2,14 @@ package noise
 
 import (
 	"github.com/KEINOS/go-noise/pkg/custom"
+	"github.com/KEINOS/go-noise/pkg/improvedperlin"
 	"github.com/KEINOS/go-noise/pkg/opensimplex"
+	"github.com/KEINOS/go-noise/pkg/opensimplex2"
 	"github.com/KEINOS/go-noise/pkg/perlin"
+	"github.com/KEINOS/go-noise/pkg/simplex"
+	"github.com/KEINOS/go-noise/pkg/value"
+	"github.com/KEINOS/go-noise/pkg/white"
+	"github.com/KEINOS/go-noise/pkg/worley"
 	"github.com/pkg/errors"
 )
 
@@ -19,6 +25,22 @@ const (
 	OpenSimplex
 	// Custom uses the user-defined function to generate noise.
 	Custom
+	// ImprovedPerlin noise type. Ken Perlin's "Improved Noise" (2002) normalized
+	// to the range of [-1, 1].
+	ImprovedPerlin
+	// Simplex noise type. Ken Perlin's classic Simplex noise.
+	Simplex
+	// OpenSimplex2F noise type. The faster variant of OpenSimplex2.
+	OpenSimplex2F
+	// OpenSimplex2S noise type. The smoother variant of OpenSimplex2.
+	OpenSimplex2S
+	// Worley noise type. Cellular noise of the F1 Euclidean distance by default.
+	Worley
+	// Value noise type. Lattice value noise with the cubic interpolation.
+	Value
+	// White noise type. Deterministic white noise which hashes the seed and the
+	// coordinates into a uniform value.
+	White
 )
 
 // ----------------------------------------------------------------------------
@@ -26,25 +48,30 @@ const (
 // ----------------------------------------------------------------------------
 
 // Generator is an interface for noise generator.
+//
+// The generators of this package are safe to evaluate concurrently. Custom is
+// safe as long as the user-defined function is.
 type Generator interface {
 	// Eval32 returns a float32 noise value at given coordinates. The maximum
-	// number of arguments is three.
+	// number of arguments is four.
 	//
 	// Example:
 	//   Eval32(x)
 	//   Eval32(x, y)
 	//   Eval32(x, y, z)
+	//   Eval32(x, y, z, w)
 	//
 	// Implementations of this method must return the same value if the seed
 	// value is the same.
 	Eval32(dim ...float32) float32
 	// Eval64 returns a float64 noise value at given coordinates. The maximum
-	// number of arguments is three.
+	// number of arguments is four.
 	//
 	// Example:
 	//   Eval64(x)
 	//   Eval64(x, y)
 	//   Eval64(x, y, z)
+	//   Eval64(x, y, z, w)
 	//
 	// Implementations of this method must return the same value if the seed
 	// value is the same.
@@ -53,6 +80,41 @@ type Generator interface {
 	SetEval64(f func(seed int64, dim ...float64) float64) error
 }
 
+// Generator1D is an optional interface of Generator to evaluate 1-dimensional
+// noise without the variadic argument. The generators of this package implement
+// it and the dimension-specific interfaces below.
+//
+// In hot loops, type-assert the generator to use them without allocation:
+//
+//	if g, ok := gen.(noise.Generator2D); ok {
+//	  v := g.Eval2D64(x, y)
+//	}
+type Generator1D interface {
+	Eval1D32(x float32) float32
+	Eval1D64(x float64) float64
+}
+
+// Generator2D is an optional interface of Generator to evaluate 2-dimensional
+// noise without the variadic argument.
+type Generator2D interface {
+	Eval2D32(x, y float32) float32
+	Eval2D64(x, y float64) float64
+}
+
+// Generator3D is an optional interface of Generator to evaluate 3-dimensional
+// noise without the variadic argument.
+type Generator3D interface {
+	Eval3D32(x, y, z float32) float32
+	Eval3D64(x, y, z float64) float64
+}
+
+// Generator4D is an optional interface of Generator to evaluate 4-dimensional
+// noise without the variadic argument.
+type Generator4D interface {
+	Eval4D32(x, y, z, w float32) float32
+	Eval4D64(x, y, z, w float64) float64
+}
+
 // ----------------------------------------------------------------------------
 //  Constructor
 // ----------------------------------------------------------------------------
@@ -66,6 +128,20 @@ func New(noiseType Algo, seed int64) (Generator, error) {
 		return opensimplex.New(seed), nil
 	case Custom:
 		return custom.New(seed), nil
+	case ImprovedPerlin:
+		return improvedperlin.New(seed), nil
+	case Simplex:
+		return simplex.New(seed), nil
+	case OpenSimplex2F:
+		return opensimplex2.New(seed), nil
+	case OpenSimplex2S:
+		return opensimplex2.NewSmooth(seed), nil
+	case Worley:
+		return worley.New(seed), nil
+	case Value:
+		return value.New(seed), nil
+	case White:
+		return white.New(seed), nil
 	}
 
 	return nil, errors.New("unknown noise type")
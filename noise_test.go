@@ -53,3 +53,160 @@ func TestNew_is_in_range_opensimplex(t *testing.T) {
 		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
 	}
 }
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_improved_perlin(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.ImprovedPerlin, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_simplex(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.Simplex, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_opensimplex2f(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.OpenSimplex2F, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_opensimplex2s(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.OpenSimplex2S, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_worley(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.Worley, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_value(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.Value, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+//nolint:dupl // let lines be duplicate with other tests for readability
+func TestNew_is_in_range_white(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		//nolint:gosec // Use of weak random number generator is OK here
+		seed := rand.New(rand.NewSource(time.Now().UnixNano())).Int63()
+
+		g, err := noise.New(noise.White, seed)
+
+		require.NoError(t, err, "it should not return an error")
+		require.NotNil(t, g, "it should not be nil")
+
+		v := g.Eval64(rand.ExpFloat64())
+
+		require.GreaterOrEqual(t, v, float64(-1), "it should be in the range of -1 to 1")
+		require.LessOrEqual(t, v, float64(1), "it should be in the range of -1 to 1")
+	}
+}
+
+func TestGeneratorND_zero_allocation_via_interface(t *testing.T) {
+	for _, algo := range []noise.Algo{
+		noise.Perlin, noise.OpenSimplex, noise.ImprovedPerlin, noise.Simplex, noise.OpenSimplex2F, noise.OpenSimplex2S,
+		noise.Worley, noise.Value, noise.White,
+	} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		g1, ok1 := gen.(noise.Generator1D)
+		g2, ok2 := gen.(noise.Generator2D)
+		g3, ok3 := gen.(noise.Generator3D)
+		g4, ok4 := gen.(noise.Generator4D)
+
+		require.True(t, ok1 && ok2 && ok3 && ok4, "built-in generators should implement the dimension-specific interfaces")
+
+		// Build the cached state before measuring.
+		_ = gen.Eval64(0)
+
+		require.Zero(t, testing.AllocsPerRun(100, func() {
+			_ = g1.Eval1D64(0.1)
+			_ = g2.Eval2D64(0.1, 0.2)
+			_ = g3.Eval3D64(0.1, 0.2, 0.3)
+			_ = g4.Eval4D64(0.1, 0.2, 0.3, 0.4)
+			_ = g1.Eval1D32(0.1)
+			_ = g2.Eval2D32(0.1, 0.2)
+			_ = g3.Eval3D32(0.1, 0.2, 0.3)
+			_ = g4.Eval4D32(0.1, 0.2, 0.3, 0.4)
+		}), "algo: %v", algo)
+	}
+}
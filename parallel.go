@@ -0,0 +1,178 @@
+package noise
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// EvalGrid2D32Parallel is the goroutine-sharded version of EvalGrid2D32. The rows
+// of the grid are split across the given number of goroutines.
+//
+// If workers is less than 1, runtime.GOMAXPROCS(0) is used. The results are the
+// same as EvalGrid2D32 for the same generator. It stops and returns an error if
+// ctx is canceled, in which case dst may be partially filled.
+//
+// gen must be safe for concurrent use. The built-in generators are.
+func EvalGrid2D32Parallel(ctx context.Context, gen Generator, dst []float32, g *Grid, workers int) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	return evalRows(ctx, g.Height, workers, func(y int) error {
+		row := rowOf2D(g, y)
+
+		return EvalGrid2D32(gen, dst[y*g.Width:(y+1)*g.Width], &row)
+	})
+}
+
+// EvalGrid2D64Parallel is the goroutine-sharded version of EvalGrid2D64. The rows
+// of the grid are split across the given number of goroutines.
+//
+// If workers is less than 1, runtime.GOMAXPROCS(0) is used. The results are the
+// same as EvalGrid2D64 for the same generator. It stops and returns an error if
+// ctx is canceled, in which case dst may be partially filled.
+//
+// gen must be safe for concurrent use. The built-in generators are.
+func EvalGrid2D64Parallel(ctx context.Context, gen Generator, dst []float64, g *Grid, workers int) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	return evalRows(ctx, g.Height, workers, func(y int) error {
+		row := rowOf2D(g, y)
+
+		return EvalGrid2D64(gen, dst[y*g.Width:(y+1)*g.Width], &row)
+	})
+}
+
+// EvalGrid3D32Parallel is the goroutine-sharded version of EvalGrid3D32. The rows
+// of each z-slice of the grid are split across the given number of goroutines.
+//
+// If workers is less than 1, runtime.GOMAXPROCS(0) is used. The results are the
+// same as EvalGrid3D32 for the same generator. It stops and returns an error if
+// ctx is canceled, in which case dst may be partially filled.
+//
+// gen must be safe for concurrent use. The built-in generators are.
+func EvalGrid3D32Parallel(ctx context.Context, gen Generator, dst []float32, g *Grid, workers int) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	return evalRows(ctx, g.Depth*g.Height, workers, func(i int) error {
+		row := rowOf3D(g, i)
+
+		return EvalGrid3D32(gen, dst[i*g.Width:(i+1)*g.Width], &row)
+	})
+}
+
+// EvalGrid3D64Parallel is the goroutine-sharded version of EvalGrid3D64. The rows
+// of each z-slice of the grid are split across the given number of goroutines.
+//
+// If workers is less than 1, runtime.GOMAXPROCS(0) is used. The results are the
+// same as EvalGrid3D64 for the same generator. It stops and returns an error if
+// ctx is canceled, in which case dst may be partially filled.
+//
+// gen must be safe for concurrent use. The built-in generators are.
+func EvalGrid3D64Parallel(ctx context.Context, gen Generator, dst []float64, g *Grid, workers int) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	return evalRows(ctx, g.Depth*g.Height, workers, func(i int) error {
+		row := rowOf3D(g, i)
+
+		return EvalGrid3D64(gen, dst[i*g.Width:(i+1)*g.Width], &row)
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// evalRows calls evalRow with the row indexes from 0 to numRows-1 using the given
+// number of goroutines. It returns the first error occurred or the error of ctx
+// if canceled.
+func evalRows(ctx context.Context, numRows, workers int, evalRow func(row int) error) error {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > numRows {
+		workers = numRows
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		next     int64 = -1
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for row := int(atomic.AddInt64(&next, 1)); row < numRows; row = int(atomic.AddInt64(&next, 1)) {
+				err := ctx.Err()
+				if err == nil {
+					err = evalRow(row)
+				}
+
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						cancel()
+					})
+
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return errors.Wrap(firstErr, "failed to evaluate the grid")
+	}
+
+	return nil
+}
+
+// rowOf2D returns the y'th row of the 2D grid as a grid of height 1.
+func rowOf2D(g *Grid, y int) Grid {
+	return Grid{
+		X:      g.X,
+		Y:      g.AtY(y),
+		StepX:  g.StepX,
+		Width:  g.Width,
+		Height: 1,
+	}
+}
+
+// rowOf3D returns the i'th row of the 3D grid as a grid of height and depth 1.
+// The i'th row is the (i % Height)'th row of the (i / Height)'th z-slice.
+func rowOf3D(g *Grid, i int) Grid {
+	return Grid{
+		X:      g.X,
+		Y:      g.AtY(i % g.Height),
+		Z:      g.AtZ(i / g.Height),
+		StepX:  g.StepX,
+		Width:  g.Width,
+		Height: 1,
+		Depth:  1,
+	}
+}
@@ -0,0 +1,125 @@
+package noise_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalGridParallel_identical_to_single_thread(t *testing.T) {
+	g := &noise.Grid{
+		X: -1.3, Y: 0.7, Z: 2.1,
+		StepX: 0.013, StepY: 0.029, StepZ: 0.17,
+		Width: 33, Height: 17, Depth: 5,
+	}
+
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		for _, workers := range []int{0, 1, 3, 1000} {
+			single2D32, multi2D32 := make([]float32, g.Len2D()), make([]float32, g.Len2D())
+			single2D64, multi2D64 := make([]float64, g.Len2D()), make([]float64, g.Len2D())
+			single3D32, multi3D32 := make([]float32, g.Len3D()), make([]float32, g.Len3D())
+			single3D64, multi3D64 := make([]float64, g.Len3D()), make([]float64, g.Len3D())
+
+			ctx := context.Background()
+
+			require.NoError(t, noise.EvalGrid2D32(gen, single2D32, g))
+			require.NoError(t, noise.EvalGrid2D32Parallel(ctx, gen, multi2D32, g, workers))
+			require.NoError(t, noise.EvalGrid2D64(gen, single2D64, g))
+			require.NoError(t, noise.EvalGrid2D64Parallel(ctx, gen, multi2D64, g, workers))
+			require.NoError(t, noise.EvalGrid3D32(gen, single3D32, g))
+			require.NoError(t, noise.EvalGrid3D32Parallel(ctx, gen, multi3D32, g, workers))
+			require.NoError(t, noise.EvalGrid3D64(gen, single3D64, g))
+			require.NoError(t, noise.EvalGrid3D64Parallel(ctx, gen, multi3D64, g, workers))
+
+			require.Equal(t, single2D32, multi2D32, "algo: %v, workers: %d", algo, workers)
+			require.Equal(t, single2D64, multi2D64, "algo: %v, workers: %d", algo, workers)
+			require.Equal(t, single3D32, multi3D32, "algo: %v, workers: %d", algo, workers)
+			require.Equal(t, single3D64, multi3D64, "algo: %v, workers: %d", algo, workers)
+		}
+	}
+}
+
+func TestEvalGridParallel_canceled(t *testing.T) {
+	gen, err := noise.New(noise.Perlin, 100)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	g := &noise.Grid{StepX: 0.1, StepY: 0.1, StepZ: 0.1, Width: 10, Height: 10, Depth: 10}
+
+	err = noise.EvalGrid2D32Parallel(ctx, gen, make([]float32, g.Len2D()), g, 2)
+	require.True(t, errors.Is(err, context.Canceled), "it should return the error of the context")
+
+	err = noise.EvalGrid2D64Parallel(ctx, gen, make([]float64, g.Len2D()), g, 2)
+	require.True(t, errors.Is(err, context.Canceled), "it should return the error of the context")
+
+	err = noise.EvalGrid3D32Parallel(ctx, gen, make([]float32, g.Len3D()), g, 2)
+	require.True(t, errors.Is(err, context.Canceled), "it should return the error of the context")
+
+	err = noise.EvalGrid3D64Parallel(ctx, gen, make([]float64, g.Len3D()), g, 2)
+	require.True(t, errors.Is(err, context.Canceled), "it should return the error of the context")
+}
+
+func TestEvalGridParallel_invalid_grid(t *testing.T) {
+	gen, err := noise.New(noise.Custom, 100)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	g := &noise.Grid{Width: 10, Height: 10, Depth: 10}
+
+	// Short destination
+	require.Error(t, noise.EvalGrid2D32Parallel(ctx, gen, nil, g, 2))
+	require.Error(t, noise.EvalGrid2D64Parallel(ctx, gen, nil, g, 2))
+	require.Error(t, noise.EvalGrid3D32Parallel(ctx, gen, nil, g, 2))
+	require.Error(t, noise.EvalGrid3D64Parallel(ctx, gen, nil, g, 2))
+
+	// Custom generator without the function set
+	require.Error(t, noise.EvalGrid2D64Parallel(ctx, gen, make([]float64, g.Len2D()), g, 2))
+	require.Error(t, noise.EvalGrid3D64Parallel(ctx, gen, make([]float64, g.Len3D()), g, 2))
+}
+
+// Run with the "-race" flag to detect the data race.
+func TestGenerator_concurrent_eval(t *testing.T) {
+	const numRoutines = 8
+
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex} {
+		// Share a single generator which has not been evaluated yet.
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		expect := make([]float64, numRoutines)
+		actual := make([]float64, numRoutines)
+
+		for i := range expect {
+			ref, err := noise.New(algo, 100)
+			require.NoError(t, err)
+
+			expect[i] = ref.Eval64(float64(i)/10, 0.5, 0.25)
+		}
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < numRoutines; i++ {
+			wg.Add(1)
+
+			go func(i int) {
+				defer wg.Done()
+
+				_ = gen.Eval32(float32(i)/10, 0.5)
+				actual[i] = gen.Eval64(float64(i)/10, 0.5, 0.25)
+			}(i)
+		}
+
+		wg.Wait()
+
+		require.Equal(t, expect, actual, "algo: %v", algo)
+	}
+}
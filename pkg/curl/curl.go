@@ -0,0 +1,139 @@
+/*
+Package curl provides curl noise, the divergence-free vector field built on top
+of any noise generator. It is useful to move particles as an incompressible
+flow, such as smoke or water, without sinks and sources.
+
+The noise generator is used as the potential field and the curl of it is the
+velocity. In 2D, the velocity of the scalar potential ψ is (∂ψ/∂y, -∂ψ/∂x). In
+3D, the vector potential (ψ1, ψ2, ψ3) is sampled from the same generator at the
+shifted points, so the components are not correlated.
+
+The partial derivatives of the potential are computed by noise.EvalDeriv2D64
+and noise.EvalDeriv3D64, or the float32 versions of them. If the generator
+implements noise.DerivativeGenerator, such as Perlin and OpenSimplex, the
+analytic derivatives are always used. Otherwise they are approximated by the
+central finite differences.
+
+Note that the Perlin generator, which follows go-perlin, switches from the 3D
+noise to the 2D noise of (x, y) where z < 0. The potential is not continuous
+there, and since the components of the vector potential are shifted along z as
+well, the 3D velocity of Perlin jumps on the planes z = 0, z = -12.8 and
+z = -61.3. Use OpenSimplex or keep z positive to avoid it.
+
+	gen := curl.New(src)
+	vx, vy := gen.Eval2D64(x, y)
+*/
+package curl
+
+import "github.com/KEINOS/go-noise"
+
+// potentialOffsets are the shifts of the sample point for each component of
+// the vector potential in 3D. The z shifts move the z < 0 switch of Perlin to
+// the planes of z = 0, -12.8 and -61.3.
+var potentialOffsets = [3][3]float64{
+	{0, 0, 0},
+	{31.4, 47.2, 12.8},
+	{-23.9, 17.5, 61.3},
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a curl noise generator of the potential field src.
+func New(src noise.Generator) *Generator {
+	return &Generator{
+		Source: src,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds the potential field of the curl noise. Unlike the other
+// generators, it returns a vector instead of a value, so it does not implement
+// noise.Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines as long as the Source is. But the field
+// must not be changed while evaluating.
+type Generator struct {
+	// Source is the noise generator of the potential field. It must support 2D
+	// for Eval2D* and 3D for Eval3D*. If it is nil, the velocity is 0.
+	Source noise.Generator
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval2D32 returns the float32 velocity at (x, y). It is the float32 version of
+// Eval2D64, so the Source may support float32 only.
+func (g *Generator) Eval2D32(x, y float32) (vx, vy float32) {
+	if g.Source == nil {
+		return 0, 0
+	}
+
+	_, dx, dy := noise.EvalDeriv2D32(g.Source, x, y)
+
+	return dy, -dx
+}
+
+// Eval2D64 returns the float64 velocity at (x, y), the curl of the potential
+// field. The divergence of the velocity is 0 and the velocity is perpendicular
+// to the gradient of the potential, so it flows along the contour lines.
+func (g *Generator) Eval2D64(x, y float64) (vx, vy float64) {
+	if g.Source == nil {
+		return 0, 0
+	}
+
+	_, dx, dy := noise.EvalDeriv2D64(g.Source, x, y)
+
+	return dy, -dx
+}
+
+// Eval3D32 returns the float32 velocity at (x, y, z). It is the float32 version
+// of Eval3D64, so the Source may support float32 only.
+func (g *Generator) Eval3D32(x, y, z float32) (vx, vy, vz float32) {
+	if g.Source == nil {
+		return 0, 0, 0
+	}
+
+	var grad [3][3]float32
+
+	for i, o := range potentialOffsets {
+		_, grad[i][0], grad[i][1], grad[i][2] = noise.EvalDeriv3D32(
+			g.Source, x+float32(o[0]), y+float32(o[1]), z+float32(o[2]),
+		)
+	}
+
+	vx = grad[2][1] - grad[1][2]
+	vy = grad[0][2] - grad[2][0]
+	vz = grad[1][0] - grad[0][1]
+
+	return vx, vy, vz
+}
+
+// Eval3D64 returns the float64 velocity at (x, y, z), the curl of the vector
+// potential field. The divergence of the velocity is 0 wherever the potential
+// is smooth. Note that 3D OpenSimplex noise has tiny discontinuities at some
+// points, where the velocity jumps slightly.
+func (g *Generator) Eval3D64(x, y, z float64) (vx, vy, vz float64) {
+	if g.Source == nil {
+		return 0, 0, 0
+	}
+
+	// grad[i] is the gradient of the i-th component of the potential.
+	var grad [3][3]float64
+
+	for i, o := range potentialOffsets {
+		_, grad[i][0], grad[i][1], grad[i][2] = noise.EvalDeriv3D64(g.Source, x+o[0], y+o[1], z+o[2])
+	}
+
+	vx = grad[2][1] - grad[1][2]
+	vy = grad[0][2] - grad[2][0]
+	vz = grad[1][0] - grad[0][1]
+
+	return vx, vy, vz
+}
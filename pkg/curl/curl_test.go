@@ -0,0 +1,197 @@
+package curl_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/curl"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+	"github.com/stretchr/testify/require"
+)
+
+func newSource(t *testing.T, algo noise.Algo, seed int64) noise.Generator {
+	t.Helper()
+
+	src, err := noise.New(algo, seed)
+	require.NoError(t, err)
+
+	return src
+}
+
+// sources returns the potential fields with the analytical derivatives and
+// the one with the finite differences.
+func sources(t *testing.T) map[string]noise.Generator {
+	t.Helper()
+
+	return map[string]noise.Generator{
+		"Perlin":      newSource(t, noise.Perlin, 100),
+		"OpenSimplex": newSource(t, noise.OpenSimplex, 100),
+		"fBm":         fractal.New(newSource(t, noise.OpenSimplex, 100), 100),
+	}
+}
+
+// requireDivergenceFree asserts that the sum of the partial derivatives of the
+// velocity, the divergence, is negligible compared to the derivatives which
+// cancel each other out. The error of the numerical differentiation remains.
+func requireDivergenceFree(t *testing.T, partials []float64, msgAndArgs ...interface{}) {
+	t.Helper()
+
+	var div, sum float64
+
+	for _, d := range partials {
+		div += d
+		sum += math.Abs(d)
+	}
+
+	require.LessOrEqual(t, math.Abs(div), sum*1e-3, msgAndArgs...)
+}
+
+func TestGenerator_divergence_free_2D(t *testing.T) {
+	const h = 1e-6
+
+	for name, src := range sources(t) {
+		gen := curl.New(src)
+
+		for i := 0.; i < 50; i++ {
+			x, y := i*0.173-3.1, i*0.0917+0.05
+
+			vxR, _ := gen.Eval2D64(x+h, y)
+			vxL, _ := gen.Eval2D64(x-h, y)
+			_, vyU := gen.Eval2D64(x, y+h)
+			_, vyD := gen.Eval2D64(x, y-h)
+
+			dvx, dvy := (vxR-vxL)/(2*h), (vyU-vyD)/(2*h)
+
+			require.NotZero(t, dvx, "%s: the field should not be uniform", name)
+			requireDivergenceFree(t, []float64{dvx, dvy}, "%s: at (%v, %v)", name, x, y)
+		}
+	}
+}
+
+func TestGenerator_divergence_free_3D(t *testing.T) {
+	const h = 1e-6
+
+	for name, src := range sources(t) {
+		gen := curl.New(src)
+
+		for i := 0.; i < 50; i++ {
+			x, y, z := i*0.173-3.1, i*0.0917+0.05, i*0.061+0.02
+
+			vxR, _, _ := gen.Eval3D64(x+h, y, z)
+			vxL, _, _ := gen.Eval3D64(x-h, y, z)
+			_, vyU, _ := gen.Eval3D64(x, y+h, z)
+			_, vyD, _ := gen.Eval3D64(x, y-h, z)
+			_, _, vzF := gen.Eval3D64(x, y, z+h)
+			_, _, vzB := gen.Eval3D64(x, y, z-h)
+
+			dvx, dvy, dvz := (vxR-vxL)/(2*h), (vyU-vyD)/(2*h), (vzF-vzB)/(2*h)
+
+			require.NotZero(t, dvx, "%s: the field should not be uniform", name)
+			requireDivergenceFree(t, []float64{dvx, dvy, dvz}, "%s: at (%v, %v, %v)", name, x, y, z)
+		}
+	}
+}
+
+func TestGenerator_flows_along_contour_2D(t *testing.T) {
+	for name, src := range sources(t) {
+		gen := curl.New(src)
+
+		for i := 0.; i < 50; i++ {
+			x, y := i*0.173-3.1, i*0.0917+0.05
+
+			vx, vy := gen.Eval2D64(x, y)
+			_, dx, dy := noise.EvalDeriv2D64(src, x, y)
+
+			require.InDelta(t, 0, vx*dx+vy*dy, 1e-12, "%s: the velocity should be perpendicular to the gradient", name)
+		}
+	}
+}
+
+func TestGenerator_analytic_potential(t *testing.T) {
+	src := newSource(t, noise.Custom, 100)
+
+	// ψ = sin(x) * cos(y) * cos(z) / 2
+	require.NoError(t, src.SetEval64(func(seed int64, dim ...float64) float64 {
+		v := math.Sin(dim[0]) * math.Cos(dim[1]) / 2
+		if len(dim) > 2 {
+			v *= math.Cos(dim[2])
+		}
+
+		return v
+	}))
+
+	gen := curl.New(src)
+
+	x, y := 0.3, -1.2
+
+	vx, vy := gen.Eval2D64(x, y)
+
+	require.InDelta(t, -math.Sin(x)*math.Sin(y)/2, vx, 1e-9)
+	require.InDelta(t, -math.Cos(x)*math.Cos(y)/2, vy, 1e-9)
+}
+
+func TestGenerator_nil_source(t *testing.T) {
+	gen := curl.New(nil)
+
+	vx, vy := gen.Eval2D64(0.1, 0.2)
+
+	require.Zero(t, vx)
+	require.Zero(t, vy)
+
+	vx, vy, vz := gen.Eval3D64(0.1, 0.2, 0.3)
+
+	require.Zero(t, vx)
+	require.Zero(t, vy)
+	require.Zero(t, vz)
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := curl.New(newSource(t, noise.OpenSimplex, 100))
+
+	vx64, vy64 := gen.Eval2D64(0.25, 0.5)
+	vx32, vy32 := gen.Eval2D32(0.25, 0.5)
+
+	require.InDelta(t, vx64, float64(vx32), 1e-6)
+	require.InDelta(t, vy64, float64(vy32), 1e-6)
+
+	vx64, vy64, vz64 := gen.Eval3D64(0.25, 0.5, 0.125)
+	vx32, vy32, vz32 := gen.Eval3D32(0.25, 0.5, 0.125)
+
+	require.InDelta(t, vx64, float64(vx32), 1e-6)
+	require.InDelta(t, vy64, float64(vy32), 1e-6)
+	require.InDelta(t, vz64, float64(vz32), 1e-6)
+}
+
+func TestGenerator_float32_only_source(t *testing.T) {
+	src := newSource(t, noise.Custom, 100)
+
+	// ψ = sin(x) * cos(y) * cos(z) / 2, without the float64 function.
+	require.NoError(t, src.SetEval32(func(seed int64, dim ...float32) float32 {
+		v := math.Sin(float64(dim[0])) * math.Cos(float64(dim[1])) / 2
+		if len(dim) > 2 {
+			v *= math.Cos(float64(dim[2]))
+		}
+
+		return float32(v)
+	}))
+
+	gen := curl.New(src)
+
+	x, y := 0.3, -1.2
+
+	require.NotPanics(t, func() {
+		vx, vy := gen.Eval2D32(float32(x), float32(y))
+
+		require.InDelta(t, -math.Sin(x)*math.Sin(y)/2, float64(vx), 1e-3)
+		require.InDelta(t, -math.Cos(x)*math.Cos(y)/2, float64(vy), 1e-3)
+	})
+
+	require.NotPanics(t, func() {
+		vx, vy, vz := gen.Eval3D32(float32(x), float32(y), 0.125)
+
+		for _, v := range []float32{vx, vy, vz} {
+			require.False(t, math.IsNaN(float64(v)))
+		}
+	})
+}
@@ -0,0 +1,37 @@
+package curl_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/curl"
+)
+
+func ExampleNew() {
+	const seed = 100
+
+	src, err := noise.New(noise.Perlin, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gen := curl.New(src)
+
+	// Move a particle along the flow by the Euler method.
+	x, y := 0.25, 0.5
+
+	for step := 0; step < 3; step++ {
+		vx, vy := gen.Eval2D64(x, y)
+
+		x += vx * 0.1
+		y += vy * 0.1
+
+		fmt.Printf("%0.4f;%0.4f\n", x, y)
+	}
+
+	// Output:
+	// 0.2627;0.6861
+	// 0.3611;0.8034
+	// 0.5026;0.8126
+}
@@ -10,10 +10,14 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/pkg/errors"
 )
 
 // Generator holds parameters of user-defined noise generator.
+//
+// The evaluation methods are safe to call concurrently from multiple goroutines
+// as long as the user-defined function is. Set the functions before evaluating.
 type Generator struct {
 	// func32 is the user-defined function for float32.
 	func32 func(seed int64, dim ...float32) float32
@@ -41,6 +45,108 @@ func (n *Generator) Eval64(dim ...float64) float64 {
 	return n.func64(n.Seed, dim...)
 }
 
+// Eval1D32 returns a float32 noise value using the user-defined function at
+// the 1-dimensional coordinates. It is equivalent to Eval32(x).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return n.func32(n.Seed, x)
+}
+
+// Eval2D32 returns a float32 noise value using the user-defined function at
+// the 2-dimensional coordinates. It is equivalent to Eval32(x, y).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return n.func32(n.Seed, x, y)
+}
+
+// Eval3D32 returns a float32 noise value using the user-defined function at
+// the 3-dimensional coordinates. It is equivalent to Eval32(x, y, z).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return n.func32(n.Seed, x, y, z)
+}
+
+// Eval4D32 returns a float32 noise value using the user-defined function at
+// the 4-dimensional coordinates. It is equivalent to Eval32(x, y, z, w).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return n.func32(n.Seed, x, y, z, w)
+}
+
+// Eval1D64 returns a float64 noise value using the user-defined function at
+// the 1-dimensional coordinates. It is equivalent to Eval64(x).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return n.func64(n.Seed, x)
+}
+
+// Eval2D64 returns a float64 noise value using the user-defined function at
+// the 2-dimensional coordinates. It is equivalent to Eval64(x, y).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return n.func64(n.Seed, x, y)
+}
+
+// Eval3D64 returns a float64 noise value using the user-defined function at
+// the 3-dimensional coordinates. It is equivalent to Eval64(x, y, z).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return n.func64(n.Seed, x, y, z)
+}
+
+// Eval4D64 returns a float64 noise value using the user-defined function at
+// the 4-dimensional coordinates. It is equivalent to Eval64(x, y, z, w).
+//
+// Unlike the other generators, it may allocate since the coordinates are passed
+// to the variadic user-defined function.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return n.func64(n.Seed, x, y, z, w)
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of
+// panicking if the user-defined function is not set, or if no coordinate is
+// given.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if n.func32 == nil {
+		return 0, errors.New("float32 evaluation function is not set")
+	}
+
+	if len(dim) == 0 {
+		return 0, errors.New("unsupported number of dimensions: 0. at least one coordinate is required")
+	}
+
+	return n.func32(n.Seed, dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of
+// panicking if the user-defined function is not set, or if no coordinate is
+// given.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if n.func64 == nil {
+		return 0, errors.New("float64 evaluation function is not set")
+	}
+
+	if len(dim) == 0 {
+		return 0, errors.New("unsupported number of dimensions: 0. at least one coordinate is required")
+	}
+
+	return n.func64(n.Seed, dim...), nil
+}
+
 // SetEval32 sets the user-defined noise generator function for float32.
 func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
 	// Check the function.
@@ -74,3 +180,131 @@ func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error
 
 	return nil
 }
+
+// EvalGrid2D32 fills dst with the float32 noise values of the user-defined
+// function at the sample points of the 2D grid. It returns an error if dst is
+// too short for the grid or if the function is not set.
+//
+// The slice of coordinates passed to the user-defined function is re-used
+// between the calls. The function must not keep it.
+func (n *Generator) EvalGrid2D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if n.func32 == nil {
+		return errors.New("float32 evaluation function is not set")
+	}
+
+	dim := make([]float32, 2)
+
+	for y := 0; y < g.Height; y++ {
+		dim[1] = float32(g.AtY(y))
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			dim[0] = float32(g.AtX(x))
+			row[x] = n.func32(n.Seed, dim...)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid2D64 fills dst with the float64 noise values of the user-defined
+// function at the sample points of the 2D grid. It returns an error if dst is
+// too short for the grid or if the function is not set.
+//
+// The slice of coordinates passed to the user-defined function is re-used
+// between the calls. The function must not keep it.
+func (n *Generator) EvalGrid2D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if n.func64 == nil {
+		return errors.New("float64 evaluation function is not set")
+	}
+
+	dim := make([]float64, 2)
+
+	for y := 0; y < g.Height; y++ {
+		dim[1] = g.AtY(y)
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			dim[0] = g.AtX(x)
+			row[x] = n.func64(n.Seed, dim...)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D32 fills dst with the float32 noise values of the user-defined
+// function at the sample points of the 3D grid. It returns an error if dst is
+// too short for the grid or if the function is not set.
+//
+// The slice of coordinates passed to the user-defined function is re-used
+// between the calls. The function must not keep it.
+func (n *Generator) EvalGrid3D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if n.func32 == nil {
+		return errors.New("float32 evaluation function is not set")
+	}
+
+	dim := make([]float32, 3)
+
+	for z := 0; z < g.Depth; z++ {
+		dim[2] = float32(g.AtZ(z))
+
+		for y := 0; y < g.Height; y++ {
+			dim[1] = float32(g.AtY(y))
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				dim[0] = float32(g.AtX(x))
+				row[x] = n.func32(n.Seed, dim...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D64 fills dst with the float64 noise values of the user-defined
+// function at the sample points of the 3D grid. It returns an error if dst is
+// too short for the grid or if the function is not set.
+//
+// The slice of coordinates passed to the user-defined function is re-used
+// between the calls. The function must not keep it.
+func (n *Generator) EvalGrid3D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if n.func64 == nil {
+		return errors.New("float64 evaluation function is not set")
+	}
+
+	dim := make([]float64, 3)
+
+	for z := 0; z < g.Depth; z++ {
+		dim[2] = g.AtZ(z)
+
+		for y := 0; y < g.Height; y++ {
+			dim[1] = g.AtY(y)
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				dim[0] = g.AtX(x)
+				row[x] = n.func64(n.Seed, dim...)
+			}
+		}
+	}
+
+	return nil
+}
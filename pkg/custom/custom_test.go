@@ -3,6 +3,7 @@ package custom
 import (
 	"testing"
 
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -44,3 +45,116 @@ func TestSetEval64_function_returns_out_of_range(t *testing.T) {
 	require.Error(t, err, "if the function returns a value out of range, an error should be returned")
 	assert.Contains(t, err.Error(), "the function must return a value between -1 and 1")
 }
+
+func TestEvalGrid_passes_coordinates(t *testing.T) {
+	gen := New(12345)
+
+	require.NoError(t, gen.SetEval32(func(seed int64, dim ...float32) float32 {
+		return (dim[0] + dim[len(dim)-1]) / 100
+	}))
+	require.NoError(t, gen.SetEval64(func(seed int64, dim ...float64) float64 {
+		return (dim[0] + dim[len(dim)-1]) / 100
+	}))
+
+	g := &grid.Grid{X: 1, Y: 2, Z: 3, StepX: 1, StepY: 1, StepZ: 1, Width: 3, Height: 2, Depth: 2}
+
+	out2D32 := make([]float32, g.Len2D())
+	out2D64 := make([]float64, g.Len2D())
+	out3D32 := make([]float32, g.Len3D())
+	out3D64 := make([]float64, g.Len3D())
+
+	require.NoError(t, gen.EvalGrid2D32(out2D32, g))
+	require.NoError(t, gen.EvalGrid2D64(out2D64, g))
+	require.NoError(t, gen.EvalGrid3D32(out3D32, g))
+	require.NoError(t, gen.EvalGrid3D64(out3D64, g))
+
+	// (x, y) = (3, 3) at index 5 of 2D
+	assert.Equal(t, float32(0.06), out2D32[5])
+	assert.Equal(t, 0.06, out2D64[5])
+	// (x, y, z) = (3, 3, 4) at index 11 of 3D
+	assert.Equal(t, float32(0.07), out3D32[11])
+	assert.Equal(t, 0.07, out3D64[11])
+}
+
+func TestEvalGrid_no_function_assigned(t *testing.T) {
+	gen := New(12345)
+	g := &grid.Grid{Width: 2, Height: 2, Depth: 2}
+
+	require.Error(t, gen.EvalGrid2D32(make([]float32, 8), g))
+	require.Error(t, gen.EvalGrid2D64(make([]float64, 8), g))
+	require.Error(t, gen.EvalGrid3D32(make([]float32, 8), g))
+	require.Error(t, gen.EvalGrid3D64(make([]float64, 8), g))
+}
+
+func TestEvalGrid_short_destination(t *testing.T) {
+	gen := New(12345)
+	g := &grid.Grid{Width: 2, Height: 2, Depth: 2}
+
+	require.Error(t, gen.EvalGrid2D32(nil, g))
+	require.Error(t, gen.EvalGrid2D64(nil, g))
+	require.Error(t, gen.EvalGrid3D32(nil, g))
+	require.Error(t, gen.EvalGrid3D64(nil, g))
+}
+
+func TestEvalE_no_function_assigned(t *testing.T) {
+	gen := New(12345)
+
+	require.NotPanics(t, func() {
+		_, err := gen.Eval32E(1.0, 2.0, 3.0)
+
+		require.Error(t, err, "if the function is not assigned, it should return an error")
+		assert.Contains(t, err.Error(), "float32 evaluation function is not set")
+
+		_, err = gen.Eval64E(1.0, 2.0, 3.0)
+
+		require.Error(t, err, "if the function is not assigned, it should return an error")
+		assert.Contains(t, err.Error(), "float64 evaluation function is not set")
+	})
+}
+
+func TestEvalE(t *testing.T) {
+	gen := New(12345)
+
+	require.NoError(t, gen.SetEval32(func(seed int64, dim ...float32) float32 {
+		return float32(len(dim)) / 10
+	}))
+	require.NoError(t, gen.SetEval64(func(seed int64, dim ...float64) float64 {
+		return float64(len(dim)) / 10
+	}))
+
+	v32, err := gen.Eval32E(1, 2, 3, 4, 5)
+
+	require.NoError(t, err, "custom function should accept any number of dimensions")
+	assert.Equal(t, float32(0.5), v32)
+
+	v64, err := gen.Eval64E(1, 2, 3, 4, 5)
+
+	require.NoError(t, err, "custom function should accept any number of dimensions")
+	assert.Equal(t, 0.5, v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E()
+	require.Error(t, err, "no coordinate should be an error")
+}
+
+func TestEvalND_equals_to_variadic(t *testing.T) {
+	gen := New(12345)
+
+	require.NoError(t, gen.SetEval32(func(seed int64, dim ...float32) float32 {
+		return dim[len(dim)-1] / float32(len(dim))
+	}))
+	require.NoError(t, gen.SetEval64(func(seed int64, dim ...float64) float64 {
+		return dim[len(dim)-1] / float64(len(dim))
+	}))
+
+	assert.Equal(t, gen.Eval32(0.1), gen.Eval1D32(0.1))
+	assert.Equal(t, gen.Eval32(0.1, 0.2), gen.Eval2D32(0.1, 0.2))
+	assert.Equal(t, gen.Eval32(0.1, 0.2, 0.3), gen.Eval3D32(0.1, 0.2, 0.3))
+	assert.Equal(t, gen.Eval32(0.1, 0.2, 0.3, 0.4), gen.Eval4D32(0.1, 0.2, 0.3, 0.4))
+	assert.Equal(t, gen.Eval64(0.1), gen.Eval1D64(0.1))
+	assert.Equal(t, gen.Eval64(0.1, 0.2), gen.Eval2D64(0.1, 0.2))
+	assert.Equal(t, gen.Eval64(0.1, 0.2, 0.3), gen.Eval3D64(0.1, 0.2, 0.3))
+	assert.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), gen.Eval4D64(0.1, 0.2, 0.3, 0.4))
+}
@@ -0,0 +1,55 @@
+package fractal_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+)
+
+func ExampleNew() {
+	const seed = 100
+
+	src, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gen := fractal.New(src, seed)
+	gen.Octaves = 4
+	gen.Frequency = 0.5
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f\n", gen.Eval64(x/10), gen.Eval64(x/10, 0.3))
+	}
+
+	// Output:
+	// -0.2243;0.3750
+	// -0.1960;0.3536
+	// -0.2606;0.3050
+}
+
+func ExampleMode() {
+	src, err := noise.New(noise.OpenSimplex, 100)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	gen := fractal.New(src, 100)
+
+	for _, mode := range []fractal.Mode{
+		fractal.FBm, fractal.Billow, fractal.Ridged, fractal.HybridMulti, fractal.HeteroTerrain,
+	} {
+		gen.Mode = mode
+
+		fmt.Printf("%0.4f\n", gen.Eval64(0.2, 0.3))
+	}
+
+	// Output:
+	// 0.1056
+	// -0.6939
+	// 0.4611
+	// 0.0149
+	// -0.3763
+}
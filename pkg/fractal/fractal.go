@@ -0,0 +1,481 @@
+/*
+Package fractal is a wrapper of any noise generator which layers the octaves of
+the source noise. It implements github.com/KEINOS/go-noise/noise interface
+itself, so it can be used anywhere a noise generator is expected.
+
+Each octave samples the source at a higher frequency (multiplied by Lacunarity)
+and with a lower amplitude (multiplied by Gain) than the previous one. How the
+octaves are combined is chosen by the Mode, such as the fractal Brownian motion
+(fBm), billow and ridged multifractal. The result is normalized by the total
+amplitude, so the value stays in the range of [-1, 1] as long as the source
+does.
+
+Since the source is sampled many times with the same seed, each octave is
+shifted by a coordinate offset derived from the Seed of the wrapper to avoid the
+correlation between the octaves. For example, the lattice points of Perlin noise
+would otherwise overlap at the origin in every octave.
+
+It supports 1 to 4 dimensions.
+*/
+package fractal
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/hash"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxDim is the maximum number of dimensions supported.
+	maxDim = 4
+	// offsetRange is the maximum absolute value of the per-octave offsets.
+	offsetRange = 256
+)
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a fractal noise generator which layers the octaves of src. The
+// seed is used for the per-octave offsets and not for src.
+//
+// The default parameters are FBm mode, 6 octaves, frequency of 1, lacunarity
+// of 2, gain of 0.5, offset of 1 and weight gain of 2.
+func New(src noise.Generator, seed int64) *Generator {
+	return &Generator{
+		Source:     src,
+		Seed:       seed,
+		Mode:       FBm,
+		Octaves:    6,
+		Frequency:  1,
+		Lacunarity: 2,
+		Gain:       0.5,
+		Offset:     1,
+		WeightGain: 2,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Mode
+// ----------------------------------------------------------------------------
+
+// Mode is the way to combine the octaves.
+type Mode int
+
+const (
+	// FBm is the fractal Brownian motion. It is the plain sum of the octaves.
+	FBm Mode = iota
+	// Billow is the sum of the absolute values of the octaves. It produces puffy
+	// shapes such as clouds and rocks.
+	Billow
+	// Ridged is the ridged multifractal of F. Kenton Musgrave. Each octave is
+	// inverted around Offset and squared to form sharp ridges, and weighted by
+	// the previous octave times WeightGain. So the details appear on the ridges
+	// and the valleys stay smooth, such as mountain ranges.
+	Ridged
+	// HybridMulti is the hybrid multifractal of F. Kenton Musgrave. Each octave
+	// is shifted by Offset and weighted by the product of the previous octaves.
+	// So the low areas stay smooth and the high areas get rough, such as eroded
+	// terrain with flat valleys.
+	HybridMulti
+	// HeteroTerrain is the heterogeneous terrain of F. Kenton Musgrave. Each
+	// octave is shifted by Offset and scaled by the current value. So the
+	// roughness varies with the altitude, such as sea floors and plateaus.
+	HeteroTerrain
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for the fractal noise. It is an
+// implementation of Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines as long as the Source is. But the
+// fields must not be changed while evaluating.
+type Generator struct {
+	// Source is the noise generator to layer. It must not be nil.
+	Source noise.Generator
+	// Seed holds the seed value for the per-octave offsets.
+	Seed int64
+	// Mode is the way to combine the octaves.
+	Mode Mode
+	// Octaves is the number of the layers. Less than 1 is treated as 1.
+	Octaves int
+	// Frequency is the frequency of the first octave. The coordinates are
+	// multiplied by it.
+	Frequency float64
+	// Lacunarity is the frequency multiplier between the octaves. Usually 2.
+	Lacunarity float64
+	// Gain is the amplitude multiplier between the octaves, also known as
+	// persistence. Usually 0.5. The greater it is, the rougher the noise is.
+	//
+	// In Musgrave's terms, it is Lacunarity^-H where H is the fractal increment.
+	Gain float64
+	// Offset is the value to invert the octaves around in Ridged mode. The
+	// ridges are at where the source is 0. Usually 1, and the sensible range is
+	// 0.5 to 1.5. The greater it is, the wider the ridges are.
+	//
+	// In HybridMulti and HeteroTerrain modes, it is the value to add to the
+	// octaves. Usually 1, and the sensible range is 1 to 2. The greater it is,
+	// the more even the roughness is. Less than 1 makes the octaves negative
+	// and the value may be clipped at -1 or 1.
+	Offset float64
+	// WeightGain is the multiplier of the previous octave to weight the next
+	// one in Ridged mode. Usually 2, and the sensible range is 1 to 4. The
+	// greater it is, the more details appear on the ridges. 0 means only the
+	// first octave.
+	WeightGain float64
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 fractal noise value for the given coordinates.
+// The Source is evaluated in float32, so it may support float32 only.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 fractal noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 fractal noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	v, _ := n.eval(1, [maxDim]float64{float64(x)}, false, true)
+
+	return float32(v)
+}
+
+// Eval2D32 returns a float32 fractal noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	v, _ := n.eval(2, [maxDim]float64{float64(x), float64(y)}, false, true)
+
+	return float32(v)
+}
+
+// Eval3D32 returns a float32 fractal noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	v, _ := n.eval(3, [maxDim]float64{float64(x), float64(y), float64(z)}, false, true)
+
+	return float32(v)
+}
+
+// Eval4D32 returns a float32 fractal noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	v, _ := n.eval(4, [maxDim]float64{float64(x), float64(y), float64(z), float64(w)}, false, true)
+
+	return float32(v)
+}
+
+// Eval1D64 returns a float64 fractal noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	v, _ := n.eval(1, [maxDim]float64{x}, false, false)
+
+	return v
+}
+
+// Eval2D64 returns a float64 fractal noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	v, _ := n.eval(2, [maxDim]float64{x, y}, false, false)
+
+	return v
+}
+
+// Eval3D64 returns a float64 fractal noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	v, _ := n.eval(3, [maxDim]float64{x, y, z}, false, false)
+
+	return v
+}
+
+// Eval4D64 returns a float64 fractal noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	v, _ := n.eval(4, [maxDim]float64{x, y, z, w}, false, false)
+
+	return v
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4, the Source is nil or
+// the Source fails to evaluate strictly.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	for i, d := range dim {
+		p[i] = float64(d)
+	}
+
+	v, err := n.eval(len(dim), p, true, true)
+
+	return float32(v), err
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4, the Source is nil or
+// the Source fails to evaluate strictly.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	copy(p[:], dim)
+
+	return n.eval(len(dim), p, true, false)
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the Source instead.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in Fractal type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the Source instead.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in Fractal type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// eval returns the octaves at the point p of numDim dimensions combined by the
+// Mode and normalized by the total amplitude. If strict is true, the Source is
+// evaluated via noise.Eval64E, or noise.Eval32E if single is true, and the
+// first error is returned. If single is true, the Source is evaluated in
+// float32, so it may support float32 only.
+func (n *Generator) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	if n.Source == nil {
+		if strict {
+			return 0, errors.New("the source generator is not set")
+		}
+
+		return 0, nil
+	}
+
+	octaves := n.Octaves
+	if octaves < 1 {
+		octaves = 1
+	}
+
+	// The maximum of the octave in Ridged mode is the square of the farthest end
+	// of [Offset-1, Offset].
+	maxRidge := math.Max(n.Offset*n.Offset, (n.Offset-1)*(n.Offset-1))
+
+	sum, total := 0., 0.
+	freq, amp, weight := n.Frequency, 1., 1.
+
+	// The multifractal modes are not linear. The bounds are obtained by the
+	// source of -1 and 1 everywhere, since they are monotonic with Offset >= 1.
+	var multi, lower, upper multifractal
+
+	for i := 0; i < octaves; i++ {
+		var q [maxDim]float64
+
+		for axis := 0; axis < numDim; axis++ {
+			q[axis] = p[axis]*freq + offset(n.Seed, i, axis)
+		}
+
+		v, err := n.sample(numDim, q, strict, single)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to evaluate the octave %d", i)
+		}
+
+		peak := 1.
+
+		switch n.Mode {
+		case Billow:
+			v = 2*math.Abs(v) - 1
+		case Ridged:
+			v = n.Offset - math.Abs(v)
+			v *= v * weight
+			weight = math.Max(0, math.Min(1, v*n.WeightGain))
+			peak = maxRidge
+		case HybridMulti, HeteroTerrain:
+			multi.add(n.Mode, i, v+n.Offset, amp)
+			lower.add(n.Mode, i, n.Offset-1, amp)
+			upper.add(n.Mode, i, n.Offset+1, amp)
+		}
+
+		sum += v * amp
+		total += math.Abs(amp) * peak
+		freq *= n.Lacunarity
+		amp *= n.Gain
+	}
+
+	switch n.Mode {
+	case Ridged:
+		// The octaves of Ridged mode are in the range of [0, 1].
+		return clamp(sum/total*2 - 1), nil
+	case HybridMulti, HeteroTerrain:
+		if upper.value == lower.value {
+			return 0, nil
+		}
+
+		return clamp((multi.value-lower.value)/(upper.value-lower.value)*2 - 1), nil
+	}
+
+	return clamp(sum / total), nil
+}
+
+// sample returns the value of the Source at the point q of numDim dimensions.
+// If single is true, it is evaluated in float32.
+func (n *Generator) sample(numDim int, q [maxDim]float64, strict, single bool) (float64, error) {
+	if single {
+		return sample32(n.Source, numDim, q, strict)
+	}
+
+	if strict {
+		// Copy to keep q on the stack in the non-strict path.
+		dim := make([]float64, numDim)
+		copy(dim, q[:numDim])
+
+		return noise.Eval64E(n.Source, dim...)
+	}
+
+	switch numDim {
+	case 1:
+		return noise.Eval1D64(n.Source, q[0]), nil
+	case 2:
+		return noise.Eval2D64(n.Source, q[0], q[1]), nil
+	case 3:
+		return noise.Eval3D64(n.Source, q[0], q[1], q[2]), nil
+	}
+
+	return noise.Eval4D64(n.Source, q[0], q[1], q[2], q[3]), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: multifractal
+// ----------------------------------------------------------------------------
+
+// multifractal accumulates the octaves of HybridMulti and HeteroTerrain modes.
+type multifractal struct {
+	value  float64
+	weight float64
+}
+
+// add accumulates the signal, which is the source value plus Offset, of the
+// octave with the amplitude in the way of the mode.
+func (m *multifractal) add(mode Mode, octave int, signal, amp float64) {
+	signal *= amp
+
+	if octave == 0 {
+		m.value = signal
+		m.weight = signal
+
+		return
+	}
+
+	if mode == HeteroTerrain {
+		m.value += signal * m.value
+
+		return
+	}
+
+	m.weight = math.Min(1, m.weight)
+	m.value += signal * m.weight
+	m.weight *= signal
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Fractal supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// sample32 returns the float32 value of gen at the point q of numDim dimensions
+// as float64.
+func sample32(gen noise.Generator, numDim int, q [maxDim]float64, strict bool) (float64, error) {
+	if strict {
+		dim := make([]float32, numDim)
+
+		for i := range dim {
+			dim[i] = float32(q[i])
+		}
+
+		v, err := noise.Eval32E(gen, dim...)
+
+		return float64(v), err
+	}
+
+	switch numDim {
+	case 1:
+		return float64(noise.Eval1D32(gen, float32(q[0]))), nil
+	case 2:
+		return float64(noise.Eval2D32(gen, float32(q[0]), float32(q[1]))), nil
+	case 3:
+		return float64(noise.Eval3D32(gen, float32(q[0]), float32(q[1]), float32(q[2]))), nil
+	}
+
+	return float64(noise.Eval4D32(gen, float32(q[0]), float32(q[1]), float32(q[2]), float32(q[3]))), nil
+}
+
+// offset returns the coordinate offset of the octave for the axis in the range
+// of [-offsetRange, offsetRange).
+func offset(seed int64, octave, axis int) float64 {
+	h := hash.Mix(uint64(seed) ^ hash.Primes[axis]*uint64(octave+1))
+
+	return (hash.ToUnit(h)*2 - 1) * offsetRange
+}
+
+// clamp returns v in the range of [-1, 1] to absorb the rounding error.
+func clamp(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
@@ -0,0 +1,334 @@
+package fractal_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/testgen"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+	"github.com/stretchr/testify/require"
+)
+
+// evalOnly hides the optional interfaces of the embedded generator.
+type evalOnly struct {
+	noise.Generator
+}
+
+func newSource(t *testing.T, algo noise.Algo) noise.Generator {
+	t.Helper()
+
+	src, err := noise.New(algo, 100)
+	require.NoError(t, err)
+
+	return src
+}
+
+var modes = []fractal.Mode{fractal.FBm, fractal.Billow, fractal.Ridged, fractal.HybridMulti, fractal.HeteroTerrain}
+
+func TestGenerator_is_in_range(t *testing.T) {
+	for _, algo := range []noise.Algo{noise.Perlin, noise.OpenSimplex, noise.Value} {
+		for _, mode := range modes {
+			gen := fractal.New(newSource(t, algo), 100)
+			gen.Mode = mode
+
+			for d := 1; d <= 4; d++ {
+				minV, maxV := math.Inf(1), math.Inf(-1)
+
+				for i := 0; i < 5000; i++ {
+					f := float64(i)
+					v := gen.Eval64([]float64{f * 0.037, f * 0.011, f * 0.023, f * 0.007}[:d]...)
+
+					minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+				}
+
+				require.GreaterOrEqual(t, minV, -1., "algo %v, mode %v, %dD should be in the range of -1 to 1", algo, mode, d)
+				require.LessOrEqual(t, maxV, 1., "algo %v, mode %v, %dD should be in the range of -1 to 1", algo, mode, d)
+				require.Less(t, minV+0.5, maxV, "algo %v, mode %v, %dD should be spread in the range", algo, mode, d)
+			}
+		}
+	}
+}
+
+func TestGenerator_normalization(t *testing.T) {
+	// Constant source returns the constant regardless of the parameters.
+	src := newSource(t, noise.Custom)
+	require.NoError(t, src.SetEval64(func(seed int64, dim ...float64) float64 { return 0.75 }))
+
+	gen := fractal.New(src, 100)
+
+	for _, gain := range []float64{0, 0.25, 0.5, 1, 2} {
+		gen.Gain = gain
+
+		require.InDelta(t, 0.75, gen.Eval64(0.1, 0.2), 1e-12, "gain: %v", gain)
+	}
+}
+
+func TestGenerator_modes_of_constant_source(t *testing.T) {
+	for _, test := range []struct {
+		mode   fractal.Mode
+		source float64
+		expect float64
+	}{
+		{fractal.FBm, -0.5, -0.5},
+		{fractal.Billow, -0.5, 0},
+		{fractal.Billow, 1, 1},
+		{fractal.Billow, 0, -1},
+		// Ridges are at where the source is 0.
+		{fractal.Ridged, 0, 1},
+		{fractal.Ridged, -1, -1},
+		{fractal.Ridged, 1, -1},
+		// Multifractals are normalized by the bounds of the source.
+		{fractal.HybridMulti, -1, -1},
+		{fractal.HybridMulti, 1, 1},
+		{fractal.HeteroTerrain, -1, -1},
+		{fractal.HeteroTerrain, 1, 1},
+	} {
+		gen := fractal.New(testgen.Constant(test.source), 100)
+		gen.Mode = test.mode
+
+		require.InDelta(t, test.expect, gen.Eval64(0.1, 0.2), 1e-12, "mode %v, source %v", test.mode, test.source)
+	}
+}
+
+func TestGenerator_ridged_weight(t *testing.T) {
+	gen := fractal.New(testgen.Constant(0.5), 100)
+	gen.Mode = fractal.Ridged
+	gen.Octaves = 2
+	gen.Gain = 1
+
+	// The first octave is (1-0.5)^2 = 0.25. The second one is weighted by
+	// 0.25*WeightGain.
+	for _, weightGain := range []float64{0, 2, 4, 8} {
+		gen.WeightGain = weightGain
+
+		weight := math.Min(1, 0.25*weightGain)
+		expect := (0.25+0.25*weight)/2*2 - 1
+
+		require.InDelta(t, expect, gen.Eval64(0.1), 1e-12, "weight gain: %v", weightGain)
+	}
+}
+
+func TestGenerator_multifractal(t *testing.T) {
+	gen := fractal.New(testgen.Constant(0), 100)
+	gen.Octaves = 2
+	gen.Gain = 1
+
+	// Hybrid: 1 + 1*min(1, 1) = 2 between the bounds of 0 and 2 + 2*min(1, 2) = 4.
+	gen.Mode = fractal.HybridMulti
+	require.InDelta(t, 2./4*2-1, gen.Eval64(0.1), 1e-12)
+
+	// Hetero: 1 + 1*1 = 2 between the bounds of 0 and 2 + 2*2 = 6.
+	gen.Mode = fractal.HeteroTerrain
+	require.InDelta(t, 2./6*2-1, gen.Eval64(0.1), 1e-12)
+
+	// The weight of hybrid is capped at 1 but the value of hetero is not.
+	gen.Octaves = 3
+
+	gen.Mode = fractal.HybridMulti
+	require.InDelta(t, 3./(4+2)*2-1, gen.Eval64(0.1), 1e-12)
+
+	gen.Mode = fractal.HeteroTerrain
+	require.InDelta(t, 4./(6+2*6)*2-1, gen.Eval64(0.1), 1e-12)
+}
+
+func TestGenerator_deterministic_output(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex)
+
+	for _, test := range []struct {
+		mode   fractal.Mode
+		expect [4]float64
+	}{
+		{fractal.FBm, [4]float64{-0.189921184340011, 0.370584640409431, -0.135319666092196, 0.143850090852543}},
+		{fractal.Billow, [4]float64{-0.408110547130044, -0.227335416929081, -0.635962546732767, -0.583931500899119}},
+		{fractal.Ridged, [4]float64{-0.0934800770812628, -0.404459899845851, 0.320419500020058, 0.27268093761631}},
+		{fractal.HybridMulti, [4]float64{-0.413792078666614, 0.297199322900736, -0.283781677701755, 0.0592802237470031}},
+		{fractal.HeteroTerrain, [4]float64{-0.62265028242411, -0.0880274983018472, -0.597090093945565, -0.342531331787157}},
+	} {
+		gen := fractal.New(src, 100)
+		gen.Mode = test.mode
+
+		actual := [4]float64{
+			gen.Eval64(0.1),
+			gen.Eval64(0.1, 0.2),
+			gen.Eval64(0.1, 0.2, 0.3),
+			gen.Eval64(0.1, 0.2, 0.3, 0.4),
+		}
+
+		for i := range actual {
+			require.InDelta(t, test.expect[i], actual[i], 1e-12, "mode %v, %dD", test.mode, i+1)
+		}
+	}
+}
+
+func TestGenerator_octaves_less_than_one(t *testing.T) {
+	gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+	gen.Octaves = 1
+
+	expect := gen.Eval64(0.1, 0.2)
+
+	for _, octaves := range []int{0, -1} {
+		gen.Octaves = octaves
+
+		require.Equal(t, expect, gen.Eval64(0.1, 0.2), "octaves %d should be treated as 1", octaves)
+	}
+}
+
+func TestGenerator_octaves_are_decorrelated(t *testing.T) {
+	// Perlin noise is 0 at every lattice point. Without the offsets, the fBm of
+	// it would also be 0 at the integer coordinates.
+	gen := fractal.New(newSource(t, noise.Perlin), 100)
+
+	require.NotZero(t, gen.Eval64(0, 0))
+	require.NotZero(t, gen.Eval64(1, 1))
+}
+
+func TestGenerator_continuous(t *testing.T) {
+	const (
+		eps   = 1e-7
+		delta = 1e-4
+	)
+
+	for _, mode := range modes {
+		gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+		gen.Mode = mode
+
+		for i := 0; i < 1000; i++ {
+			f := float64(i) * 0.0173
+
+			require.InDelta(t, gen.Eval64(f), gen.Eval64(f+eps), delta, "mode %v", mode)
+			require.InDelta(t, gen.Eval64(f, -f), gen.Eval64(f+eps, -f), delta, "mode %v", mode)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f), gen.Eval64(f, -f+eps, 2*f), delta, "mode %v", mode)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f, 3*f), gen.Eval64(f, -f, 2*f, 3*f+eps), delta, "mode %v", mode)
+		}
+	}
+}
+
+func TestGenerator_seed(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex)
+	gen := fractal.New(src, 100)
+
+	before := gen.Eval64(0.3, 0.6, 0.9)
+
+	require.Equal(t, before, fractal.New(src, 100).Eval64(0.3, 0.6, 0.9), "same seed should return the same value")
+
+	gen.Seed = 101
+
+	require.NotEqual(t, before, gen.Eval64(0.3, 0.6, 0.9), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_fallback_equals_to_native(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex)
+
+	native := fractal.New(src, 100)
+	fallback := fractal.New(evalOnly{Generator: src}, 100)
+
+	require.Equal(t, native.Eval64(0.1), fallback.Eval64(0.1))
+	require.Equal(t, native.Eval64(0.1, 0.2), fallback.Eval64(0.1, 0.2))
+	require.Equal(t, native.Eval64(0.1, 0.2, 0.3), fallback.Eval64(0.1, 0.2, 0.3))
+	require.Equal(t, native.Eval64(0.1, 0.2, 0.3, 0.4), fallback.Eval64(0.1, 0.2, 0.3, 0.4))
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+
+	// The source is evaluated in float32 at the coordinates of the octaves.
+	require.InDelta(t, gen.Eval64(float64(float32(0.1))), gen.Eval32(0.1), 1e-5)
+	require.InDelta(t, gen.Eval64(float64(float32(0.1)), float64(float32(0.2))), gen.Eval32(0.1, 0.2), 1e-5)
+}
+
+func TestGenerator_float32_only_source(t *testing.T) {
+	src := newSource(t, noise.Custom)
+
+	require.NoError(t, src.SetEval32(func(seed int64, dim ...float32) float32 {
+		return float32(math.Sin(float64(dim[0])))
+	}))
+
+	gen := fractal.New(src, 100)
+
+	for _, mode := range modes {
+		gen.Mode = mode
+
+		require.NotPanics(t, func() {
+			gen.Eval32(0.1)
+			gen.Eval32(0.1, 0.2)
+			gen.Eval32(0.1, 0.2, 0.3)
+			gen.Eval32(0.1, 0.2, 0.3, 0.4)
+		}, "mode %v", mode)
+
+		v, err := gen.Eval32E(0.1, 0.2)
+
+		require.NoError(t, err, "mode %v", mode)
+		require.Equal(t, gen.Eval32(0.1, 0.2), v, "mode %v", mode)
+	}
+
+	// The octaves of sin(x) should be the same as the float64 source.
+	src64 := newSource(t, noise.Custom)
+
+	require.NoError(t, src64.SetEval64(func(seed int64, dim ...float64) float64 {
+		return math.Sin(dim[0])
+	}))
+
+	gen.Mode = fractal.FBm
+
+	require.InDelta(t, fractal.New(src64, 100).Eval64(0.1, 0.2), gen.Eval32(0.1, 0.2), 1e-5)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestGenerator_EvalE_source_error(t *testing.T) {
+	gen := fractal.New(nil, 100)
+
+	_, err := gen.Eval64E(0.1)
+	require.Error(t, err, "nil source should be an error")
+	require.Zero(t, gen.Eval64(0.1), "nil source should return 0")
+
+	// Custom without the user-defined function.
+	gen.Source = newSource(t, noise.Custom)
+
+	_, err = gen.Eval32E(0.1)
+	require.Error(t, err, "the error of the source should be returned")
+	require.Contains(t, err.Error(), "octave 0")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+		_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+	}))
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := fractal.New(newSource(t, noise.OpenSimplex), 100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
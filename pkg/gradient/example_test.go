@@ -0,0 +1,46 @@
+package gradient_test
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/gradient"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
+)
+
+func ExampleTerrain() {
+	g := gradient.Terrain()
+
+	for _, v := range []float64{-0.8, 0.03, 0.5, 0.9} {
+		fmt.Printf("%v: %v\n", v, g.At(v))
+	}
+
+	// Output:
+	// -0.8: {0 0 162 255}
+	// 0.03: {115 182 163 255}
+	// 0.5: {192 192 43 255}
+	// 0.9: {204 204 204 255}
+}
+
+func ExampleRender() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 250x250 pixels of the noise from (0, 0) to (10, 10) in the heat map.
+	img := gradient.Render(gen, gradient.Heat(), image.Rect(0, 0, 250, 250), noiseimage.Viewport{
+		Scale: 1. / 25,
+	})
+
+	fmt.Println(img.Bounds())
+	fmt.Println(img.RGBAAt(100, 100))
+
+	// Output:
+	// (0,0)-(250,250)
+	// {255 39 0 255}
+}
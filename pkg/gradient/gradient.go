@@ -0,0 +1,160 @@
+/*
+Package gradient provides the color gradients, also known as color ramps, to
+colorize the noise values. For example, to draw a terrain of water, sand, grass,
+rock and snow from the height of the noise.
+
+The gradient is defined by the color stops at the noise values, which are
+usually between -1 and 1, and interpolates the colors between them.
+
+	g := gradient.Terrain()
+
+	c := g.At(gen.Eval64(x, y)) // color.RGBA
+	img := gradient.Render(gen, g, image.Rect(0, 0, 256, 256), noiseimage.Viewport{
+		Scale: 1. / 32,
+	})
+*/
+package gradient
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+	"sort"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Interpolation
+// ----------------------------------------------------------------------------
+
+// Interpolation is the method to interpolate the colors between the stops.
+type Interpolation int
+
+const (
+	// Linear interpolates the colors linearly.
+	Linear Interpolation = iota
+	// Smoothstep eases in and out the colors around the stops by the smoothstep
+	// function, 3t^2 - 2t^3.
+	Smoothstep
+	// Constant uses the color of the lower stop without the interpolation. It
+	// produces the bands of the colors.
+	Constant
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Stop
+// ----------------------------------------------------------------------------
+
+// Stop is a control point of the gradient.
+type Stop struct {
+	// Pos is the noise value of the stop.
+	Pos float64
+	// Color is the color at Pos. It is alpha-premultiplied as color.RGBA is,
+	// so R, G and B must not exceed A. For example, the half transparent red
+	// is {R: 0x80, A: 0x80}, not {R: 0xff, A: 0x80}.
+	Color color.RGBA
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Gradient
+// ----------------------------------------------------------------------------
+
+// Gradient maps the noise values to the colors. The value beyond the stops is
+// clamped to the color of the first or the last stop.
+//
+// It holds no internal state and it is safe to call At concurrently from
+// multiple goroutines. But the fields must not be changed while evaluating.
+type Gradient struct {
+	// Stops are the control points of the gradient. They must be sorted by Pos
+	// in ascending order. If empty, the color is transparent black.
+	Stops []Stop
+	// Interpolation is the method to interpolate between the stops.
+	Interpolation Interpolation
+}
+
+// New returns a gradient of the stops interpolated by interp. The stops are
+// copied and sorted by Pos.
+func New(interp Interpolation, stops ...Stop) *Gradient {
+	sorted := make([]Stop, len(stops))
+	copy(sorted, stops)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Pos < sorted[j].Pos
+	})
+
+	return &Gradient{
+		Stops:         sorted,
+		Interpolation: interp,
+	}
+}
+
+// At returns the color of the noise value v. It can be used as the color map of
+// noiseimage.RGBA.
+func (g *Gradient) At(v float64) color.RGBA {
+	if len(g.Stops) == 0 {
+		return color.RGBA{}
+	}
+
+	last := len(g.Stops) - 1
+	i1 := sort.Search(len(g.Stops), func(i int) bool { return v < g.Stops[i].Pos })
+
+	switch {
+	case i1 == 0:
+		return g.Stops[0].Color
+	case i1 > last:
+		return g.Stops[last].Color
+	}
+
+	s0, s1 := g.Stops[i1-1], g.Stops[i1]
+	t := (v - s0.Pos) / (s1.Pos - s0.Pos)
+
+	switch g.Interpolation {
+	case Constant:
+		return s0.Color
+	case Smoothstep:
+		t = t * t * (3 - 2*t)
+	case Linear:
+	}
+
+	return lerpRGBA(s0.Color, s1.Color, t)
+}
+
+// Eval returns the color of the noise value of gen at the given coordinates.
+func (g *Gradient) Eval(gen noise.Generator, dim ...float64) color.RGBA {
+	return g.At(gen.Eval64(dim...))
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// Render returns the image of gen in the bounds of rect colorized by g. The
+// pixels are mapped to the noise coordinates by vp.
+func Render(gen noise.Generator, g *Gradient, rect image.Rectangle, vp noiseimage.Viewport) *image.RGBA {
+	img := image.NewRGBA(rect)
+
+	draw.Draw(img, rect, noiseimage.NewRGBA(gen, rect, vp, g.At), rect.Min, draw.Src)
+
+	return img
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// lerpRGBA interpolates the colors of c0 and c1 at t between 0 and 1.
+func lerpRGBA(c0, c1 color.RGBA, t float64) color.RGBA {
+	lerp := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a) + t*(float64(b)-float64(a))))
+	}
+
+	return color.RGBA{
+		R: lerp(c0.R, c1.R),
+		G: lerp(c0.G, c1.G),
+		B: lerp(c0.B, c1.B),
+		A: lerp(c0.A, c1.A),
+	}
+}
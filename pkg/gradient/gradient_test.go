@@ -0,0 +1,171 @@
+package gradient_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/gradient"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	black = color.RGBA{A: 0xff}
+	white = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	red   = color.RGBA{R: 0xff, A: 0xff}
+)
+
+func TestNew_sorted(t *testing.T) {
+	stops := []gradient.Stop{
+		{Pos: 1, Color: white},
+		{Pos: -1, Color: black},
+		{Pos: 0, Color: red},
+	}
+
+	g := gradient.New(gradient.Linear, stops...)
+
+	require.Equal(t, []gradient.Stop{
+		{Pos: -1, Color: black},
+		{Pos: 0, Color: red},
+		{Pos: 1, Color: white},
+	}, g.Stops)
+	require.Equal(t, 1., stops[0].Pos, "it should not modify the given stops")
+}
+
+func TestGradient_At(t *testing.T) {
+	stops := []gradient.Stop{
+		{Pos: -1, Color: black},
+		{Pos: 0, Color: red},
+		{Pos: 1, Color: white},
+	}
+
+	for _, test := range []struct {
+		interp gradient.Interpolation
+		v      float64
+		expect color.RGBA
+	}{
+		// At the stops and beyond them.
+		{gradient.Linear, -1, black},
+		{gradient.Linear, 0, red},
+		{gradient.Linear, 1, white},
+		{gradient.Linear, -5, black},
+		{gradient.Linear, 5, white},
+		// Between the stops.
+		{gradient.Linear, -0.5, color.RGBA{R: 128, A: 0xff}},
+		{gradient.Linear, 0.75, color.RGBA{R: 0xff, G: 191, B: 191, A: 0xff}},
+		{gradient.Smoothstep, -0.5, color.RGBA{R: 128, A: 0xff}},
+		{gradient.Smoothstep, -0.75, color.RGBA{R: 40, A: 0xff}},
+		{gradient.Smoothstep, 1, white},
+		{gradient.Constant, -0.01, black},
+		{gradient.Constant, 0.99, red},
+		{gradient.Constant, 1, white},
+	} {
+		g := gradient.New(test.interp, stops...)
+
+		require.Equal(t, test.expect, g.At(test.v), "interpolation: %d, value: %v", test.interp, test.v)
+	}
+}
+
+func TestGradient_At_alpha(t *testing.T) {
+	g := gradient.New(gradient.Linear,
+		gradient.Stop{Pos: 0, Color: color.RGBA{}},
+		gradient.Stop{Pos: 1, Color: white},
+	)
+
+	require.Equal(t, color.RGBA{R: 128, G: 128, B: 128, A: 128}, g.At(0.5))
+}
+
+func TestGradient_At_no_stops(t *testing.T) {
+	g := gradient.New(gradient.Linear)
+
+	require.Equal(t, color.RGBA{}, g.At(0))
+}
+
+func TestGradient_Eval(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	g := gradient.Terrain()
+
+	for i := 0.; i < 10; i++ {
+		x, y := i*0.37, i*0.21
+
+		require.Equal(t, g.At(gen.Eval64(x, y)), g.Eval(gen, x, y))
+	}
+}
+
+func TestPresets(t *testing.T) {
+	for name, g := range map[string]*gradient.Gradient{
+		"Grayscale": gradient.Grayscale(),
+		"Terrain":   gradient.Terrain(),
+		"Heat":      gradient.Heat(),
+		"Viridis":   gradient.Viridis(),
+	} {
+		require.GreaterOrEqual(t, len(g.Stops), 2, name)
+		require.Equal(t, -1., g.Stops[0].Pos, "%s should start at -1", name)
+		require.Equal(t, 1., g.Stops[len(g.Stops)-1].Pos, "%s should end at 1", name)
+
+		for i, s := range g.Stops {
+			require.Equal(t, uint8(0xff), s.Color.A, "%s should be opaque", name)
+
+			if i > 0 {
+				require.Less(t, g.Stops[i-1].Pos, s.Pos, "%s should be sorted", name)
+			}
+		}
+	}
+
+	require.Equal(t, black, gradient.Grayscale().At(-1))
+	require.Equal(t, white, gradient.Grayscale().At(1))
+
+	// Each call returns a new gradient.
+	g := gradient.Heat()
+	g.Stops[0].Color = white
+
+	require.Equal(t, black, gradient.Heat().At(-1))
+}
+
+func TestRender(t *testing.T) {
+	gen, err := noise.New(noise.Perlin, 100)
+	require.NoError(t, err)
+
+	g := gradient.Viridis()
+	rect := image.Rect(-3, 2, 13, 10)
+	vp := noiseimage.Viewport{X: 0.5, Y: 0.25, Scale: 0.1}
+
+	img := gradient.Render(gen, g, rect, vp)
+
+	require.Equal(t, rect, img.Bounds())
+
+	// It should be the same as the lazy image of noiseimage.
+	lazy := noiseimage.NewRGBA(gen, rect, vp, g.At)
+
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			require.Equal(t, lazy.RGBAAt(x, y), img.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestRender_translucent(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	// The half transparent red and the transparent black, alpha-premultiplied.
+	g := gradient.New(gradient.Linear,
+		gradient.Stop{Pos: -1, Color: color.RGBA{R: 0x80, A: 0x80}},
+		gradient.Stop{Pos: 1, Color: color.RGBA{}},
+	)
+	rect := image.Rect(0, 0, 8, 8)
+	vp := noiseimage.Viewport{Scale: 0.1}
+
+	img := gradient.Render(gen, g, rect, vp)
+
+	// The colors should be copied as is, not composited over the background.
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			require.Equal(t, g.At(vp.Eval(gen, x, y)), img.RGBAAt(x, y))
+		}
+	}
+}
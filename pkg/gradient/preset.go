@@ -0,0 +1,58 @@
+package gradient
+
+import "image/color"
+
+// The presets return a new gradient for the noise values between -1 and 1 on
+// each call, so the returned gradient can be modified freely.
+
+// Grayscale returns a gradient from black at -1 to white at 1.
+func Grayscale() *Gradient {
+	return New(Linear,
+		Stop{Pos: -1, Color: color.RGBA{R: 0, G: 0, B: 0, A: 0xff}},
+		Stop{Pos: 1, Color: color.RGBA{R: 255, G: 255, B: 255, A: 0xff}},
+	)
+}
+
+// Terrain returns a gradient of the terrain. The values below 0 are the water
+// and the values above are the sand, grass, dirt, rock and snow.
+//
+// The colors are the ones of the libnoise tutorial.
+func Terrain() *Gradient {
+	return New(Linear,
+		Stop{Pos: -1, Color: color.RGBA{R: 0, G: 0, B: 128, A: 0xff}},        // deep water
+		Stop{Pos: -0.25, Color: color.RGBA{R: 0, G: 0, B: 255, A: 0xff}},     // shallow water
+		Stop{Pos: 0, Color: color.RGBA{R: 0, G: 128, B: 255, A: 0xff}},       // shore
+		Stop{Pos: 0.0625, Color: color.RGBA{R: 240, G: 240, B: 64, A: 0xff}}, // sand
+		Stop{Pos: 0.125, Color: color.RGBA{R: 32, G: 160, B: 0, A: 0xff}},    // grass
+		Stop{Pos: 0.375, Color: color.RGBA{R: 224, G: 224, B: 0, A: 0xff}},   // dirt
+		Stop{Pos: 0.75, Color: color.RGBA{R: 128, G: 128, B: 128, A: 0xff}},  // rock
+		Stop{Pos: 1, Color: color.RGBA{R: 255, G: 255, B: 255, A: 0xff}},     // snow
+	)
+}
+
+// Heat returns a gradient of the heat map from black through red and yellow to
+// white.
+func Heat() *Gradient {
+	return New(Linear,
+		Stop{Pos: -1, Color: color.RGBA{R: 0, G: 0, B: 0, A: 0xff}},
+		Stop{Pos: -1. / 3, Color: color.RGBA{R: 255, G: 0, B: 0, A: 0xff}},
+		Stop{Pos: 1. / 3, Color: color.RGBA{R: 255, G: 255, B: 0, A: 0xff}},
+		Stop{Pos: 1, Color: color.RGBA{R: 255, G: 255, B: 255, A: 0xff}},
+	)
+}
+
+// Viridis returns an approximation of the viridis color map of matplotlib, which
+// is perceptually uniform and readable by color blind people.
+func Viridis() *Gradient {
+	return New(Linear,
+		Stop{Pos: -1, Color: color.RGBA{R: 0x44, G: 0x01, B: 0x54, A: 0xff}},
+		Stop{Pos: -0.75, Color: color.RGBA{R: 0x47, G: 0x2d, B: 0x7b, A: 0xff}},
+		Stop{Pos: -0.5, Color: color.RGBA{R: 0x3b, G: 0x52, B: 0x8b, A: 0xff}},
+		Stop{Pos: -0.25, Color: color.RGBA{R: 0x2c, G: 0x72, B: 0x8e, A: 0xff}},
+		Stop{Pos: 0, Color: color.RGBA{R: 0x21, G: 0x91, B: 0x8c, A: 0xff}},
+		Stop{Pos: 0.25, Color: color.RGBA{R: 0x28, G: 0xae, B: 0x80, A: 0xff}},
+		Stop{Pos: 0.5, Color: color.RGBA{R: 0x5e, G: 0xc9, B: 0x62, A: 0xff}},
+		Stop{Pos: 0.75, Color: color.RGBA{R: 0xad, G: 0xdc, B: 0x30, A: 0xff}},
+		Stop{Pos: 1, Color: color.RGBA{R: 0xfd, G: 0xe7, B: 0x25, A: 0xff}},
+	)
+}
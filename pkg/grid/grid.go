@@ -0,0 +1,99 @@
+/*
+Package grid defines a regular lattice of sample points to evaluate noise values
+in a batch.
+
+It is used by the batch evaluation methods of the generators in this module,
+such as `EvalGrid2D64` and `EvalGrid3D64`.
+*/
+package grid
+
+import "github.com/pkg/errors"
+
+// ----------------------------------------------------------------------------
+//  Type: Grid
+// ----------------------------------------------------------------------------
+
+// Grid holds the origin, the step and the size of a regular lattice of sample
+// points.
+//
+// The values are stored in row-major order. The value at (x, y) of a 2D grid is
+// at index `y*Width + x` and the value at (x, y, z) of a 3D grid is at index
+// `(z*Height+y)*Width + x`.
+type Grid struct {
+	// X is the x-axis coordinate of the first sample point.
+	X float64
+	// Y is the y-axis coordinate of the first sample point.
+	Y float64
+	// Z is the z-axis coordinate of the first sample point. Ignored in 2D.
+	Z float64
+	// StepX is the distance between the sample points along the x-axis.
+	StepX float64
+	// StepY is the distance between the sample points along the y-axis.
+	StepY float64
+	// StepZ is the distance between the sample points along the z-axis. Ignored
+	// in 2D.
+	StepZ float64
+	// Width is the number of sample points along the x-axis.
+	Width int
+	// Height is the number of sample points along the y-axis.
+	Height int
+	// Depth is the number of sample points along the z-axis. Ignored in 2D.
+	Depth int
+}
+
+// ----------------------------------------------------------------------------
+//  Methods
+// ----------------------------------------------------------------------------
+
+// AtX returns the x-axis coordinate of the i'th sample point.
+func (g *Grid) AtX(i int) float64 {
+	return g.X + float64(i)*g.StepX
+}
+
+// AtY returns the y-axis coordinate of the i'th sample point.
+func (g *Grid) AtY(i int) float64 {
+	return g.Y + float64(i)*g.StepY
+}
+
+// AtZ returns the z-axis coordinate of the i'th sample point.
+func (g *Grid) AtZ(i int) float64 {
+	return g.Z + float64(i)*g.StepZ
+}
+
+// Len2D returns the number of sample points of the grid as 2D.
+func (g *Grid) Len2D() int {
+	return g.Width * g.Height
+}
+
+// Len3D returns the number of sample points of the grid as 3D.
+func (g *Grid) Len3D() int {
+	return g.Width * g.Height * g.Depth
+}
+
+// Validate2D returns an error if the size of the grid is negative or if the
+// length of the destination, lenDst, is too short to store the grid as 2D.
+func (g *Grid) Validate2D(lenDst int) error {
+	if g.Width < 0 || g.Height < 0 {
+		return errors.Errorf("negative grid size. width: %d, height: %d", g.Width, g.Height)
+	}
+
+	if lenDst < g.Len2D() {
+		return errors.Errorf("destination is too short. got: %d, want: %d", lenDst, g.Len2D())
+	}
+
+	return nil
+}
+
+// Validate3D returns an error if the size of the grid is negative or if the
+// length of the destination, lenDst, is too short to store the grid as 3D.
+func (g *Grid) Validate3D(lenDst int) error {
+	if g.Width < 0 || g.Height < 0 || g.Depth < 0 {
+		return errors.Errorf("negative grid size. width: %d, height: %d, depth: %d", g.Width, g.Height, g.Depth)
+	}
+
+	if lenDst < g.Len3D() {
+		return errors.Errorf("destination is too short. got: %d, want: %d", lenDst, g.Len3D())
+	}
+
+	return nil
+}
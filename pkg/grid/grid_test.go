@@ -0,0 +1,61 @@
+package grid_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-noise/pkg/grid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGrid_coordinates(t *testing.T) {
+	g := grid.Grid{
+		X: 1, Y: 2, Z: 3,
+		StepX: 0.5, StepY: 0.25, StepZ: 2,
+		Width: 4, Height: 3, Depth: 2,
+	}
+
+	assert.Equal(t, 1., g.AtX(0))
+	assert.Equal(t, 2.5, g.AtX(3))
+	assert.Equal(t, 2.5, g.AtY(2))
+	assert.Equal(t, 5., g.AtZ(1))
+	assert.Equal(t, 12, g.Len2D())
+	assert.Equal(t, 24, g.Len3D())
+}
+
+func TestGrid_Validate2D(t *testing.T) {
+	g := grid.Grid{Width: 4, Height: 3}
+
+	require.NoError(t, g.Validate2D(12))
+	require.NoError(t, g.Validate2D(13), "longer destination should be allowed")
+
+	err := g.Validate2D(11)
+
+	require.Error(t, err, "short destination should be an error")
+	assert.Contains(t, err.Error(), "destination is too short")
+
+	g.Height = -1
+
+	err = g.Validate2D(12)
+
+	require.Error(t, err, "negative size should be an error")
+	assert.Contains(t, err.Error(), "negative grid size")
+}
+
+func TestGrid_Validate3D(t *testing.T) {
+	g := grid.Grid{Width: 4, Height: 3, Depth: 2}
+
+	require.NoError(t, g.Validate3D(24))
+
+	err := g.Validate3D(23)
+
+	require.Error(t, err, "short destination should be an error")
+	assert.Contains(t, err.Error(), "destination is too short")
+
+	g.Depth = -1
+
+	err = g.Validate3D(24)
+
+	require.Error(t, err, "negative size should be an error")
+	assert.Contains(t, err.Error(), "negative grid size")
+}
@@ -0,0 +1,44 @@
+package heightmap_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/heightmap"
+)
+
+func ExampleRender() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 4x3 points from (0, 0) with the interval of 0.25.
+	hm, err := heightmap.Render(gen, &noise.Grid{
+		StepX: 0.25, StepY: 0.25,
+		Width: 4, Height: 3,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(hm.Data)
+
+	// Export as RAW16 of little endian, such as for Unity.
+	var buf bytes.Buffer
+
+	if err := hm.WriteRAW16(&buf, binary.LittleEndian); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(buf.Len(), "bytes")
+
+	// Output:
+	// [32768 44712 46546 38899 27368 36884 36311 27260 22076 25551 24150 17514]
+	// 24 bytes
+}
@@ -0,0 +1,177 @@
+/*
+Package heightmap renders the noise values into a 16-bit heightmap and exports
+it in the formats which game engines and terrain tools import.
+
+  - RAW16: the headerless 16-bit values in little or big endian, such as for the
+    terrains of Unity and Unreal Engine.
+  - PGM: the binary Netpbm gray map (P5) in 8 or 16 bits.
+  - PNG: the 16-bit gray scale PNG.
+
+The noise value between -1 and 1 is mapped to the height between 0 and 65535.
+
+	g := &noise.Grid{StepX: 1. / 64, StepY: 1. / 64, Width: 513, Height: 513}
+
+	hm, err := heightmap.Render(gen, g)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = hm.WriteRAW16(w, binary.LittleEndian)
+*/
+package heightmap
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/pkg/errors"
+)
+
+// MaxPoints is the maximum number of points of a heightmap, such as 8192 x 8192.
+// It keeps the malformed sizes of the imported files from allocating too much
+// memory.
+const MaxPoints = 1 << 26
+
+// ----------------------------------------------------------------------------
+//  Type: Heightmap
+// ----------------------------------------------------------------------------
+
+// Heightmap holds the 16-bit heights of Width x Height points.
+type Heightmap struct {
+	// Data is the heights in row-major order. The height at (x, y) is at index
+	// `y*Width + x`.
+	Data []uint16
+	// Width is the number of points along the x-axis.
+	Width int
+	// Height is the number of points along the y-axis.
+	Height int
+}
+
+// New returns a flat heightmap of the size of width x height. It returns an
+// error if the size is negative or the number of points exceeds MaxPoints.
+func New(width, height int) (*Heightmap, error) {
+	if err := validateSize(width, height); err != nil {
+		return nil, err
+	}
+
+	return &Heightmap{
+		Data:   make([]uint16, width*height),
+		Width:  width,
+		Height: height,
+	}, nil
+}
+
+// Render returns the heightmap of gen at the sample points of the 2D grid. The
+// size of the heightmap is the same as the grid.
+func Render(gen noise.Generator, g *noise.Grid) (*Heightmap, error) {
+	h, err := New(g.Width, g.Height)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid grid")
+	}
+
+	values := make([]float64, g.Len2D())
+
+	if err := noise.EvalGrid2D64(gen, values, g); err != nil {
+		return nil, errors.Wrap(err, "failed to evaluate the grid")
+	}
+
+	for i, v := range values {
+		h.Data[i] = toHeight(v)
+	}
+
+	return h, nil
+}
+
+// FromImage returns the heightmap of the gray scale of img. It returns an error
+// if the number of pixels exceeds MaxPoints.
+func FromImage(img image.Image) (*Heightmap, error) {
+	b := img.Bounds()
+
+	h, err := New(b.Dx(), b.Dy())
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid image size")
+	}
+
+	for y := 0; y < h.Height; y++ {
+		for x := 0; x < h.Width; x++ {
+			c, _ := color.Gray16Model.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray16)
+
+			h.Data[y*h.Width+x] = c.Y
+		}
+	}
+
+	return h, nil
+}
+
+// At returns the height at (x, y).
+func (h *Heightmap) At(x, y int) uint16 {
+	return h.Data[y*h.Width+x]
+}
+
+// Image returns a copy of the heightmap as a 16-bit gray scale image.
+func (h *Heightmap) Image() *image.Gray16 {
+	img := image.NewGray16(image.Rect(0, 0, h.Width, h.Height))
+
+	for y := 0; y < h.Height; y++ {
+		for x := 0; x < h.Width; x++ {
+			img.SetGray16(x, y, color.Gray16{Y: h.At(x, y)})
+		}
+	}
+
+	return img
+}
+
+// validate returns an error if the size of the heightmap does not match the
+// length of the data.
+func (h *Heightmap) validate() error {
+	if h.Width < 0 || h.Height < 0 {
+		return errors.Errorf("negative heightmap size. width: %d, height: %d", h.Width, h.Height)
+	}
+
+	if len(h.Data) != h.Width*h.Height {
+		return errors.Errorf(
+			"the length of the data does not match the size. len: %d, width: %d, height: %d",
+			len(h.Data), h.Width, h.Height,
+		)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// validateSize returns an error if the size of width x height is negative or the
+// number of points exceeds MaxPoints.
+func validateSize(width, height int) error {
+	if width < 0 || height < 0 {
+		return errors.Errorf("negative heightmap size. width: %d, height: %d", width, height)
+	}
+
+	// Dividing instead of multiplying, so that the size does not overflow.
+	if width > 0 && height > MaxPoints/width {
+		return errors.Errorf(
+			"too large heightmap size. width: %d, height: %d, max points: %d", width, height, MaxPoints,
+		)
+	}
+
+	return nil
+}
+
+// toHeight maps the noise value v between -1 and 1 to the height between 0 and
+// 65535. The values out of the range are clamped and NaN is mapped to 0.
+func toHeight(v float64) uint16 {
+	u := (v + 1) / 2
+
+	switch {
+	case math.IsNaN(u), u < 0:
+		return 0
+	case u > 1:
+		return math.MaxUint16
+	}
+
+	return uint16(u*math.MaxUint16 + .5)
+}
@@ -0,0 +1,284 @@
+package heightmap_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/testgen"
+	"github.com/KEINOS/go-noise/pkg/heightmap"
+	"github.com/stretchr/testify/require"
+)
+
+func render(t *testing.T) *heightmap.Heightmap {
+	t.Helper()
+
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	hm, err := heightmap.Render(gen, &noise.Grid{StepX: 0.1, StepY: 0.1, Width: 7, Height: 5})
+	require.NoError(t, err)
+
+	return hm
+}
+
+func TestRender(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	g := &noise.Grid{X: 0.5, StepX: 0.1, StepY: 0.2, Width: 7, Height: 5}
+
+	hm, err := heightmap.Render(gen, g)
+	require.NoError(t, err)
+
+	require.Equal(t, 7, hm.Width)
+	require.Equal(t, 5, hm.Height)
+	require.Len(t, hm.Data, 35)
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			v := gen.Eval64(g.AtX(x), g.AtY(y))
+
+			require.InDelta(t, (v+1)/2*math.MaxUint16, float64(hm.At(x, y)), 0.5)
+		}
+	}
+}
+
+func TestRender_range(t *testing.T) {
+	g := &noise.Grid{Width: 1, Height: 1}
+
+	for v, want := range map[float64]uint16{-1: 0, 0: 32768, 1: 65535, -3: 0, 3: 65535} {
+		hm, err := heightmap.Render(testgen.Constant(v), g)
+		require.NoError(t, err)
+
+		require.Equal(t, want, hm.At(0, 0), "noise value: %v", v)
+	}
+}
+
+func TestRender_invalid_grid(t *testing.T) {
+	hm, err := heightmap.Render(testgen.Constant(0), &noise.Grid{Width: -1, Height: 1})
+
+	require.Error(t, err)
+	require.Nil(t, hm)
+	require.Contains(t, err.Error(), "invalid grid")
+}
+
+func TestNew(t *testing.T) {
+	hm, err := heightmap.New(3, 2)
+	require.NoError(t, err)
+	require.Equal(t, make([]uint16, 6), hm.Data)
+
+	_, err = heightmap.New(3, -2)
+	require.Error(t, err)
+
+	_, err = heightmap.New(heightmap.MaxPoints, 1)
+	require.NoError(t, err)
+
+	for _, size := range [][2]int{
+		{heightmap.MaxPoints + 1, 1},
+		{1, heightmap.MaxPoints + 1},
+		{8193, 8192},
+		{math.MaxInt32, math.MaxInt32},
+		{math.MaxInt, 2},
+	} {
+		_, err = heightmap.New(size[0], size[1])
+
+		require.Error(t, err, "size %v", size)
+		require.Contains(t, err.Error(), "too large heightmap size", "size %v", size)
+	}
+}
+
+func TestRAW16_round_trip(t *testing.T) {
+	hm := render(t)
+
+	for name, order := range map[string]binary.ByteOrder{
+		"little endian": binary.LittleEndian,
+		"big endian":    binary.BigEndian,
+	} {
+		var buf bytes.Buffer
+
+		require.NoError(t, hm.WriteRAW16(&buf, order), name)
+		require.Equal(t, 2*len(hm.Data), buf.Len(), name)
+
+		got, err := heightmap.ReadRAW16(&buf, hm.Width, hm.Height, order)
+		require.NoError(t, err, name)
+		require.Equal(t, hm, got, name)
+	}
+}
+
+func TestRAW16_byte_order(t *testing.T) {
+	hm := &heightmap.Heightmap{Data: []uint16{0x0102, 0xa0b0}, Width: 2, Height: 1}
+
+	var le, be bytes.Buffer
+
+	require.NoError(t, hm.WriteRAW16(&le, binary.LittleEndian))
+	require.NoError(t, hm.WriteRAW16(&be, binary.BigEndian))
+
+	require.Equal(t, []byte{0x02, 0x01, 0xb0, 0xa0}, le.Bytes())
+	require.Equal(t, []byte{0x01, 0x02, 0xa0, 0xb0}, be.Bytes())
+}
+
+func TestReadRAW16_error(t *testing.T) {
+	for name, size := range map[string][2]int{
+		"negative size":   {-1, 1},
+		"too large size":  {65536, 65536},
+		"overflowed size": {math.MaxInt, 2},
+	} {
+		got, err := heightmap.ReadRAW16(bytes.NewReader([]byte{1, 2}), size[0], size[1], binary.LittleEndian)
+
+		require.Error(t, err, name)
+		require.Nil(t, got, name)
+	}
+}
+
+func TestReadRAW16_short(t *testing.T) {
+	_, err := heightmap.ReadRAW16(bytes.NewReader([]byte{1, 2, 3}), 2, 1, binary.LittleEndian)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read RAW16")
+}
+
+func TestPGM16_round_trip(t *testing.T) {
+	hm := render(t)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, hm.WritePGM(&buf, 16))
+	require.True(t, strings.HasPrefix(buf.String(), "P5\n7 5\n65535\n"))
+
+	got, err := heightmap.ReadPGM(&buf)
+	require.NoError(t, err)
+	require.Equal(t, hm, got)
+}
+
+func TestPGM8_round_trip(t *testing.T) {
+	hm := &heightmap.Heightmap{Data: []uint16{0, 0x7f7f, 0x8080, 0xffff, 0x1234}, Width: 5, Height: 1}
+
+	var buf bytes.Buffer
+
+	require.NoError(t, hm.WritePGM(&buf, 8))
+	require.Equal(t, "P5\n5 1\n255\n\x00\x7f\x80\xff\x12", buf.String())
+
+	got, err := heightmap.ReadPGM(&buf)
+	require.NoError(t, err)
+
+	// The 8-bit values are scaled back to 16 bits.
+	require.Equal(t, []uint16{0, 0x7f7f, 0x8080, 0xffff, 0x1212}, got.Data)
+}
+
+func TestReadPGM_header(t *testing.T) {
+	// Comments and arbitrary whitespace in the header with the maximum value of
+	// 1000, which is stored in 2 bytes.
+	in := "P5 # comment\n2\t1\n# comment\n1000\n\x03\xe8\x01\xf4"
+
+	got, err := heightmap.ReadPGM(strings.NewReader(in))
+	require.NoError(t, err)
+	require.Equal(t, &heightmap.Heightmap{Data: []uint16{0xffff, 32768}, Width: 2, Height: 1}, got)
+}
+
+func TestReadPGM_error(t *testing.T) {
+	for name, in := range map[string]string{
+		"empty":           "",
+		"ascii format":    "P2\n1 1\n255\n0",
+		"malformed width": "P5\nw 1\n255\n\x00",
+		"negative size":   "P5\n-1 1\n255\n\x00",
+		"zero max value":  "P5\n1 1\n0\n\x00",
+		"large max value": "P5\n1 1\n65536\n\x00",
+		"short data":      "P5\n2 2\n255\n\x00",
+		"too large size":  "P5\n65536 65536\n255\n\x00",
+		"overflowed size": "P5\n9223372036854775807 2\n255\n\x00",
+		"truncated":       "P5\n1 1",
+		"out of int":      "P5\n99999999999999999999 1\n255\n\x00",
+	} {
+		got, err := heightmap.ReadPGM(strings.NewReader(in))
+
+		require.Error(t, err, name)
+		require.Nil(t, got, name)
+	}
+}
+
+func TestWritePGM_bit_depth(t *testing.T) {
+	err := render(t).WritePGM(&bytes.Buffer{}, 12)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unsupported bit depth")
+}
+
+func TestPNG_round_trip(t *testing.T) {
+	hm := render(t)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, hm.WritePNG(&buf))
+
+	img, err := png.Decode(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.IsType(t, &image.Gray16{}, img, "it should be encoded as 16-bit gray scale PNG")
+
+	got, err := heightmap.ReadPNG(&buf)
+	require.NoError(t, err)
+	require.Equal(t, hm, got)
+}
+
+func TestReadPNG_error(t *testing.T) {
+	_, err := heightmap.ReadPNG(strings.NewReader("not a png"))
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read PNG")
+}
+
+func TestReadPNG_too_large(t *testing.T) {
+	// The signature and the IHDR chunk of 65536 x 65536 16-bit gray scale
+	// without the image data.
+	ihdr := []byte("IHDR\x00\x01\x00\x00\x00\x01\x00\x00\x10\x00\x00\x00\x00")
+
+	var buf bytes.Buffer
+
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)-4)))
+	buf.Write(ihdr)
+	require.NoError(t, binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr)))
+
+	got, err := heightmap.ReadPNG(&buf)
+
+	require.Error(t, err)
+	require.Nil(t, got)
+	require.Contains(t, err.Error(), "too large heightmap size")
+}
+
+func TestFromImage(t *testing.T) {
+	img := image.NewGray(image.Rect(2, 3, 4, 4))
+	img.SetGray(2, 3, color.Gray{Y: 0x12})
+	img.SetGray(3, 3, color.Gray{Y: 0xff})
+
+	hm, err := heightmap.FromImage(img)
+
+	require.NoError(t, err)
+	require.Equal(t, &heightmap.Heightmap{Data: []uint16{0x1212, 0xffff}, Width: 2, Height: 1}, hm)
+}
+
+func TestFromImage_too_large(t *testing.T) {
+	// The bounds are checked before reading the pixels.
+	img := image.NewGray(image.Rect(0, 0, 0, 0))
+	img.Rect = image.Rect(0, 0, heightmap.MaxPoints, 2)
+
+	hm, err := heightmap.FromImage(img)
+
+	require.Error(t, err)
+	require.Nil(t, hm)
+}
+
+func TestHeightmap_invalid(t *testing.T) {
+	hm := &heightmap.Heightmap{Data: []uint16{1, 2, 3}, Width: 2, Height: 2}
+
+	require.Error(t, hm.WriteRAW16(&bytes.Buffer{}, binary.LittleEndian))
+	require.Error(t, hm.WritePGM(&bytes.Buffer{}, 16))
+	require.Error(t, hm.WritePNG(&bytes.Buffer{}))
+}
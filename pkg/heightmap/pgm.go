@@ -0,0 +1,153 @@
+package heightmap
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// WritePGM writes the heightmap to w as the binary PGM (P5) of bitDepth, 8 or
+// 16. In 8 bits, the heights are rounded to 256 levels.
+func (h *Heightmap) WritePGM(w io.Writer, bitDepth int) error {
+	if err := h.validate(); err != nil {
+		return errors.Wrap(err, "invalid heightmap")
+	}
+
+	var buf []byte
+
+	switch bitDepth {
+	case 8:
+		buf = make([]byte, len(h.Data))
+
+		for i, v := range h.Data {
+			buf[i] = uint8((uint32(v)*math.MaxUint8 + math.MaxUint16/2) / math.MaxUint16)
+		}
+	case 16:
+		// The 16-bit values of PGM are in big endian.
+		buf = make([]byte, 2*len(h.Data))
+
+		for i, v := range h.Data {
+			buf[2*i], buf[2*i+1] = uint8(v>>8), uint8(v)
+		}
+	default:
+		return errors.Errorf("unsupported bit depth: %d. it must be 8 or 16", bitDepth)
+	}
+
+	maxVal := 1<<bitDepth - 1
+
+	if _, err := fmt.Fprintf(w, "P5\n%d %d\n%d\n", h.Width, h.Height, maxVal); err != nil {
+		return errors.Wrap(err, "failed to write PGM header")
+	}
+
+	if _, err := w.Write(buf); err != nil {
+		return errors.Wrap(err, "failed to write PGM")
+	}
+
+	return nil
+}
+
+// ReadPGM reads the heightmap from the binary PGM (P5) of r. The values are
+// scaled to 16 bits by the maximum value of the header.
+func ReadPGM(r io.Reader) (*Heightmap, error) {
+	br := bufio.NewReader(r)
+
+	magic, err := readToken(br)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read PGM header")
+	}
+
+	if magic != "P5" {
+		return nil, errors.Errorf("unsupported PGM format: %q. only binary PGM (P5) is supported", magic)
+	}
+
+	// Width, height and the maximum value.
+	var header [3]int
+
+	for i := range header {
+		token, err := readToken(br)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read PGM header")
+		}
+
+		if header[i], err = strconv.Atoi(token); err != nil {
+			return nil, errors.Wrap(err, "malformed PGM header")
+		}
+	}
+
+	width, height, maxVal := header[0], header[1], header[2]
+
+	if maxVal < 1 || maxVal > math.MaxUint16 {
+		return nil, errors.Errorf("malformed PGM header. the maximum value is out of range: %d", maxVal)
+	}
+
+	h, err := New(width, height)
+	if err != nil {
+		return nil, errors.Wrap(err, "malformed PGM header")
+	}
+
+	// The data starts after a single whitespace which readToken consumed.
+	bytesPerValue := 1
+	if maxVal > math.MaxUint8 {
+		bytesPerValue = 2
+	}
+
+	buf := make([]byte, bytesPerValue*len(h.Data))
+
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, errors.Wrap(err, "failed to read PGM")
+	}
+
+	for i := range h.Data {
+		v := uint32(buf[i])
+		if bytesPerValue == 2 {
+			v = uint32(buf[2*i])<<8 | uint32(buf[2*i+1])
+		}
+
+		if v > uint32(maxVal) {
+			v = uint32(maxVal)
+		}
+
+		h.Data[i] = uint16((v*math.MaxUint16 + uint32(maxVal)/2) / uint32(maxVal))
+	}
+
+	return h, nil
+}
+
+// readToken reads a whitespace separated token of the PGM header from r. The
+// comments from '#' to the end of line are skipped. The whitespace after the
+// token is consumed.
+func readToken(r *bufio.Reader) (string, error) {
+	var token []byte
+
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			if err == io.EOF && len(token) > 0 {
+				return string(token), nil
+			}
+
+			return "", err
+		}
+
+		switch {
+		case c == '#' && len(token) == 0:
+			if _, err := r.ReadString('\n'); err != nil {
+				return "", err
+			}
+		case isSpace(c):
+			if len(token) > 0 {
+				return string(token), nil
+			}
+		default:
+			token = append(token, c)
+		}
+	}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\v' || c == '\f'
+}
@@ -0,0 +1,46 @@
+package heightmap
+
+import (
+	"bytes"
+	"image/png"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// WritePNG writes the heightmap to w as the 16-bit gray scale PNG.
+func (h *Heightmap) WritePNG(w io.Writer) error {
+	if err := h.validate(); err != nil {
+		return errors.Wrap(err, "invalid heightmap")
+	}
+
+	if err := png.Encode(w, h.Image()); err != nil {
+		return errors.Wrap(err, "failed to write PNG")
+	}
+
+	return nil
+}
+
+// ReadPNG reads the heightmap from the PNG of r. The colors are converted to the
+// 16-bit gray scale. The size of the header is checked before decoding the image,
+// so that it returns an error without the allocation if it exceeds MaxPoints.
+func ReadPNG(r io.Reader) (*Heightmap, error) {
+	// The header read by DecodeConfig is read again by Decode.
+	var header bytes.Buffer
+
+	cfg, err := png.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read PNG header")
+	}
+
+	if err := validateSize(cfg.Width, cfg.Height); err != nil {
+		return nil, errors.Wrap(err, "malformed PNG header")
+	}
+
+	img, err := png.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read PNG")
+	}
+
+	return FromImage(img)
+}
@@ -0,0 +1,49 @@
+package heightmap
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// WriteRAW16 writes the heights to w as the headerless 16-bit values in the
+// byte order of order, such as binary.LittleEndian for Unity and Unreal Engine.
+func (h *Heightmap) WriteRAW16(w io.Writer, order binary.ByteOrder) error {
+	if err := h.validate(); err != nil {
+		return errors.Wrap(err, "invalid heightmap")
+	}
+
+	buf := make([]byte, 2*len(h.Data))
+
+	for i, v := range h.Data {
+		order.PutUint16(buf[2*i:], v)
+	}
+
+	if _, err := w.Write(buf); err != nil {
+		return errors.Wrap(err, "failed to write RAW16")
+	}
+
+	return nil
+}
+
+// ReadRAW16 reads the heightmap of width x height from the headerless 16-bit
+// values of r in the byte order of order.
+func ReadRAW16(r io.Reader, width, height int, order binary.ByteOrder) (*Heightmap, error) {
+	h, err := New(width, height)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 2*len(h.Data))
+
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return nil, errors.Wrap(err, "failed to read RAW16")
+	}
+
+	for i := range h.Data {
+		h.Data[i] = order.Uint16(buf[2*i:])
+	}
+
+	return h, nil
+}
@@ -0,0 +1,41 @@
+package improvedperlin_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/improvedperlin"
+)
+
+func ExampleNew() {
+	const seed = 100
+
+	gen := improvedperlin.New(seed)
+
+	// Noise values are zero at the integer lattice points.
+	fmt.Printf("%0.4f\n", gen.Eval64(1, 2, 3))
+
+	for x := 0.; x < 3; x++ {
+		fmt.Printf("%0.4f\n", gen.Eval64(x/10+0.5, 0.5, 0.5))
+	}
+
+	// Output:
+	// 0.0000
+	// -0.2412
+	// -0.3217
+	// -0.3959
+}
+
+func ExampleGenerator_Eval64_four_dimensions() {
+	const seed = 100
+
+	gen := improvedperlin.New(seed)
+
+	for w := 0.; w < 3; w++ {
+		fmt.Printf("%0.4f\n", gen.Eval64(0.1, 0.2, 0.3, w/10))
+	}
+
+	// Output:
+	// 0.1896
+	// 0.1710
+	// 0.1279
+}
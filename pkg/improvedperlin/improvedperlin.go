@@ -0,0 +1,443 @@
+/*
+Package improvedperlin is a native implementation of Ken Perlin's "Improved Noise"
+(2002) which implements github.com/KEINOS/go-noise/noise interface.
+
+Unlike the perlin package, which wraps github.com/aquilax/go-perlin, it does not
+sum up the octaves. It returns a single octave of gradient noise normalized to
+the range of [-1, 1] in 1 to 4 dimensions.
+
+The 3D noise is the same as the reference Java implementation by Ken Perlin.
+See: https://mrl.nyu.edu/~perlin/noise/
+*/
+package improvedperlin
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// The maximum absolute values of the raw noise of each dimension. They are used
+// to normalize the noise value to the range of [-1, 1].
+//
+// At a point p in a lattice cell, the noise is the sum of the dot products of the
+// gradients of the corners c and p-c weighted by the fade curves, w_c(p). Since
+// the weights are not negative, the noise is maximized when each corner has the
+// gradient of the largest dot product. So the maximum is the one of
+//
+//	M(p) = Σ_c w_c(p) * max_g g·(p-c)
+//
+// over the cell. For 1D and 2D, it is at the center of the cell: 0.5 and 1. For
+// 3D and 4D, it is off the center and found by maximizing M(p) numerically. The
+// 3D one is at (0.5, 0.4815, 0.3553) and the 4D one is at
+// (0.5, 0.4919, 0.5184, 0.3564), up to the symmetry. TestMaxND checks them.
+const (
+	max1D = 0.5
+	max2D = 1.0
+	max3D = 1.0363538112118025
+	max4D = 1.5365823340468201
+)
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a seeded Improved Perlin noise instance.
+func New(seed int64) *Generator {
+	return &Generator{
+		Seed: seed,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for Improved Perlin noise. It is an
+// implementation of Generator interface.
+//
+// It is safe to call the evaluation methods concurrently from multiple goroutines.
+// But the Seed must not be changed while evaluating.
+type Generator struct {
+	// cache holds the *state, the permutation table built from the current seed.
+	// It is built on the first evaluation and rebuilt only when the Seed was
+	// changed.
+	cache atomic.Value
+	// Seed holds the seed value for the noise.
+	Seed int64
+}
+
+// state is the permutation table and the seed used to build it.
+type state struct {
+	// perm is the permutation of 0 to 255 repeated twice to avoid the overflow
+	// of the index.
+	perm [512]int
+	seed int64
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 Improved Perlin noise value for the given coordinates.
+// It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 Improved Perlin noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 Improved Perlin noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.Eval1D64(float64(x)))
+}
+
+// Eval2D32 returns a float32 Improved Perlin noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.Eval2D64(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 Improved Perlin noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.Eval3D64(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 Improved Perlin noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.Eval4D64(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 Improved Perlin noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return normalize(n.state().noise1(x), max1D)
+}
+
+// Eval2D64 returns a float64 Improved Perlin noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return normalize(n.state().noise2(x, y), max2D)
+}
+
+// Eval3D64 returns a float64 Improved Perlin noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return normalize(n.state().noise3(x, y, z), max3D)
+}
+
+// Eval4D64 returns a float64 Improved Perlin noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return normalize(n.state().noise4(x, y, z, w), max4D)
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in ImprovedPerlin type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in ImprovedPerlin type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// state returns the cached permutation table. It rebuilds the table only if it
+// has not been built yet or the Seed was changed.
+func (n *Generator) state() *state {
+	if c, ok := n.cache.Load().(*state); ok && c.seed == n.Seed {
+		return c
+	}
+
+	c := newState(n.Seed)
+
+	n.cache.Store(c)
+
+	return c
+}
+
+// noise1 returns the raw 1-dimensional noise value in the range of [-max1D, max1D].
+func (s *state) noise1(x float64) float64 {
+	xf := math.Floor(x)
+	xi := int(xf) & 255
+	x -= xf
+
+	return lerp(fade(x), grad1(s.perm[xi], x), grad1(s.perm[xi+1], x-1))
+}
+
+// noise2 returns the raw 2-dimensional noise value in the range of [-max2D, max2D].
+func (s *state) noise2(x, y float64) float64 {
+	xf, yf := math.Floor(x), math.Floor(y)
+	xi, yi := int(xf)&255, int(yf)&255
+	x, y = x-xf, y-yf
+	u, v := fade(x), fade(y)
+
+	p := &s.perm
+	a, b := p[xi]+yi, p[xi+1]+yi
+
+	return lerp(v,
+		lerp(u, grad2(p[a], x, y), grad2(p[b], x-1, y)),
+		lerp(u, grad2(p[a+1], x, y-1), grad2(p[b+1], x-1, y-1)),
+	)
+}
+
+// noise3 returns the raw 3-dimensional noise value in the range of [-max3D, max3D].
+// It is the same as the reference Java implementation of Ken Perlin.
+func (s *state) noise3(x, y, z float64) float64 {
+	xf, yf, zf := math.Floor(x), math.Floor(y), math.Floor(z)
+	xi, yi, zi := int(xf)&255, int(yf)&255, int(zf)&255
+	x, y, z = x-xf, y-yf, z-zf
+	u, v, w := fade(x), fade(y), fade(z)
+
+	p := &s.perm
+	a := p[xi] + yi
+	aa, ab := p[a]+zi, p[a+1]+zi
+	b := p[xi+1] + yi
+	ba, bb := p[b]+zi, p[b+1]+zi
+
+	return lerp(w,
+		lerp(v,
+			lerp(u, grad3(p[aa], x, y, z), grad3(p[ba], x-1, y, z)),
+			lerp(u, grad3(p[ab], x, y-1, z), grad3(p[bb], x-1, y-1, z)),
+		),
+		lerp(v,
+			lerp(u, grad3(p[aa+1], x, y, z-1), grad3(p[ba+1], x-1, y, z-1)),
+			lerp(u, grad3(p[ab+1], x, y-1, z-1), grad3(p[bb+1], x-1, y-1, z-1)),
+		),
+	)
+}
+
+// noise4 returns the raw 4-dimensional noise value in the range of [-max4D, max4D].
+// It extends noise3 to the 4th dimension in the same manner.
+func (s *state) noise4(x, y, z, w float64) float64 {
+	xf, yf, zf, wf := math.Floor(x), math.Floor(y), math.Floor(z), math.Floor(w)
+	xi, yi, zi, wi := int(xf)&255, int(yf)&255, int(zf)&255, int(wf)&255
+	x, y, z, w = x-xf, y-yf, z-zf, w-wf
+
+	p := &s.perm
+
+	// Interpolate the 16 corners of the hypercube. The bits of the corner index
+	// choose the lower or the upper lattice point of each axis.
+	var corners [16]float64
+
+	for c := range corners {
+		cx, cy, cz, cw := c&1, (c>>1)&1, (c>>2)&1, (c>>3)&1
+		h := p[p[p[p[xi+cx]+yi+cy]+zi+cz]+wi+cw]
+
+		corners[c] = grad4(h, x-float64(cx), y-float64(cy), z-float64(cz), w-float64(cw))
+	}
+
+	// Reduce the corners along x, y, z then w axis.
+	for i, t := range [4]float64{fade(x), fade(y), fade(z), fade(w)} {
+		for c := 0; c < 8>>i; c++ {
+			corners[c] = lerp(t, corners[2*c], corners[2*c+1])
+		}
+	}
+
+	return corners[0]
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// newState returns the permutation table shuffled by the seed.
+func newState(seed int64) *state {
+	s := &state{seed: seed}
+
+	//nolint:gosec // Use of weak random number generation is intended here.
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		s.perm[i] = v
+		s.perm[i+256] = v
+	}
+
+	return s
+}
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. ImprovedPerlin supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// normalize scales the raw noise value v by its maximum absolute value. The raw
+// value never exceeds the maximum, so the clamp only guards the rounding error of
+// the last bit.
+func normalize(v, maxAbs float64) float64 {
+	return math.Max(-1, math.Min(1, v/maxAbs))
+}
+
+// fade is the quintic interpolation curve 6t^5 - 15t^4 + 10t^3.
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
+
+// grad1 returns the dot product of x and the gradient of 1 or -1 chosen by the
+// hash.
+func grad1(hash int, x float64) float64 {
+	if hash&1 == 0 {
+		return x
+	}
+
+	return -x
+}
+
+// grad2 returns the dot product of (x, y) and one of the 8 gradients of the
+// edges and the diagonals of a square chosen by the hash.
+func grad2(hash int, x, y float64) float64 {
+	switch hash & 7 {
+	case 0:
+		return x + y
+	case 1:
+		return -x + y
+	case 2:
+		return x - y
+	case 3:
+		return -x - y
+	case 4:
+		return x
+	case 5:
+		return -x
+	case 6:
+		return y
+	default:
+		return -y
+	}
+}
+
+// grad3 returns the dot product of (x, y, z) and one of the 12 gradients of the
+// edges of a cube chosen by the hash. It is the same as the reference.
+func grad3(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	v := z
+
+	switch {
+	case h < 4:
+		v = y
+	case h == 12 || h == 14:
+		v = x
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+
+	if h&2 != 0 {
+		v = -v
+	}
+
+	return u + v
+}
+
+// grad4 returns the dot product of (x, y, z, w) and one of the 32 gradients of
+// the edges of a tesseract chosen by the hash.
+func grad4(hash int, x, y, z, w float64) float64 {
+	h := hash & 31
+
+	// Drop the axis chosen by the upper 2 bits and use the lower 3 bits for the
+	// signs of the rest.
+	var a, b, c float64
+
+	switch h >> 3 {
+	case 0:
+		a, b, c = y, z, w
+	case 1:
+		a, b, c = x, z, w
+	case 2:
+		a, b, c = x, y, w
+	default:
+		a, b, c = x, y, z
+	}
+
+	if h&1 != 0 {
+		a = -a
+	}
+
+	if h&2 != 0 {
+		b = -b
+	}
+
+	if h&4 != 0 {
+		c = -c
+	}
+
+	return a + b + c
+}
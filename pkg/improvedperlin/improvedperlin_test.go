@@ -0,0 +1,307 @@
+package improvedperlin
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// referencePerm is the permutation table of the reference Java implementation of
+// Ken Perlin. See: https://mrl.nyu.edu/~perlin/noise/
+var referencePerm = [256]int{
+	151, 160, 137, 91, 90, 15, 131, 13, 201, 95, 96, 53, 194, 233, 7, 225, 140, 36, 103, 30, 69, 142,
+	8, 99, 37, 240, 21, 10, 23, 190, 6, 148, 247, 120, 234, 75, 0, 26, 197, 62, 94, 252, 219, 203, 117,
+	35, 11, 32, 57, 177, 33, 88, 237, 149, 56, 87, 174, 20, 125, 136, 171, 168, 68, 175, 74, 165, 71,
+	134, 139, 48, 27, 166, 77, 146, 158, 231, 83, 111, 229, 122, 60, 211, 133, 230, 220, 105, 92, 41,
+	55, 46, 245, 40, 244, 102, 143, 54, 65, 25, 63, 161, 1, 216, 80, 73, 209, 76, 132, 187, 208, 89,
+	18, 169, 200, 196, 135, 130, 116, 188, 159, 86, 164, 100, 109, 198, 173, 186, 3, 64, 52, 217, 226,
+	250, 124, 123, 5, 202, 38, 147, 118, 126, 255, 82, 85, 212, 207, 206, 59, 227, 47, 16, 58, 17, 182,
+	189, 28, 42, 223, 183, 170, 213, 119, 248, 152, 2, 44, 154, 163, 70, 221, 153, 101, 155, 167, 43,
+	172, 9, 129, 22, 39, 253, 19, 98, 108, 110, 79, 113, 224, 232, 178, 185, 112, 104, 218, 246, 97,
+	228, 251, 34, 242, 193, 238, 210, 144, 12, 191, 179, 162, 241, 81, 51, 145, 235, 249, 14, 239, 107,
+	49, 192, 214, 31, 181, 199, 106, 157, 184, 84, 204, 176, 115, 121, 50, 45, 127, 4, 150, 254, 138,
+	236, 205, 93, 222, 114, 67, 29, 24, 72, 243, 141, 128, 195, 78, 66, 215, 61, 156, 180,
+}
+
+// newReferenceState returns the state which uses the permutation table of the
+// reference implementation.
+func newReferenceState() *state {
+	s := &state{}
+
+	for i, v := range referencePerm {
+		s.perm[i] = v
+		s.perm[i+256] = v
+	}
+
+	return s
+}
+
+func TestNoise3_reference_value(t *testing.T) {
+	s := newReferenceState()
+
+	// The well-known output of the reference Java implementation:
+	//   ImprovedNoise.noise(3.14, 42, 7) = 0.13691995878400012
+	require.InDelta(t, 0.13691995878400012, s.noise3(3.14, 42, 7), 1e-15)
+}
+
+// TestNoise_reference_values checks the raw values with the permutation table of
+// the reference. They are the output of testdata/improvedperlin.py, which
+// transliterates the Java implementation and extends it to 1D, 2D and 4D.
+func TestNoise_reference_values(t *testing.T) {
+	const delta = 1e-15
+
+	s := newReferenceState()
+
+	require.InDelta(t, -0.5, s.noise1(0.5), delta)
+	require.InDelta(t, -0.36523199999999983, s.noise1(3.7), delta)
+	require.InDelta(t, -0.36523200000000083, s.noise1(-12.3), delta)
+
+	require.InDelta(t, -0.5, s.noise2(0.5, 0.5), delta)
+	require.InDelta(t, -0.29645625343999993, s.noise2(3.7, -1.2), delta)
+	require.InDelta(t, 0.5551608691200003, s.noise2(-12.3, 45.6), delta)
+
+	require.InDelta(t, -0.25, s.noise3(0.5, 0.5, 0.5), delta)
+	require.InDelta(t, 0.4320759632935933, s.noise3(3.7, -1.2, 8.9), delta)
+	require.InDelta(t, 0.21331067063900422, s.noise3(-12.3, 45.6, -78.9), delta)
+
+	require.InDelta(t, -0.25, s.noise4(0.5, 0.5, 0.5, 0.5), delta)
+	require.InDelta(t, 0.5123654925702364, s.noise4(3.7, -1.2, 8.9, 0.4), delta)
+	require.InDelta(t, 0.21796416470943464, s.noise4(-12.3, 45.6, -78.9, 101.1), delta)
+}
+
+// TestMaxND checks the maximum absolute values of the raw noise by maximizing
+// M(p), the sum of the largest dot products of the corners weighted by the fade
+// curves. See the comment of the constants.
+func TestMaxND(t *testing.T) {
+	// The gradient vectors are taken from the gradient functions.
+	gradients := func(numDim, numGrad int, grad func(h int, p [4]float64) float64) [][4]float64 {
+		vectors := make([][4]float64, numGrad)
+
+		for h := range vectors {
+			for i := 0; i < numDim; i++ {
+				var e [4]float64
+				e[i] = 1
+
+				vectors[h][i] = grad(h, e)
+			}
+		}
+
+		return vectors
+	}
+
+	for _, test := range []struct {
+		grads  [][4]float64
+		numDim int
+		expect float64
+	}{
+		{gradients(1, 2, func(h int, p [4]float64) float64 { return grad1(h, p[0]) }), 1, max1D},
+		{gradients(2, 8, func(h int, p [4]float64) float64 { return grad2(h, p[0], p[1]) }), 2, max2D},
+		{gradients(3, 16, func(h int, p [4]float64) float64 { return grad3(h, p[0], p[1], p[2]) }), 3, max3D},
+		{gradients(4, 32, func(h int, p [4]float64) float64 { return grad4(h, p[0], p[1], p[2], p[3]) }), 4, max4D},
+	} {
+		require.InDelta(t, test.expect, maximizeCorners(test.numDim, test.grads), 1e-13,
+			"%dD maximum", test.numDim)
+	}
+}
+
+func TestNoise_within_max(t *testing.T) {
+	s := newState(100)
+
+	for i := 0; i < 20000; i++ {
+		f := float64(i)
+		x, y, z, w := f*0.37, f*0.11, f*0.23, f*0.07
+
+		require.LessOrEqual(t, math.Abs(s.noise1(x)), max1D)
+		require.LessOrEqual(t, math.Abs(s.noise2(x, y)), max2D)
+		require.LessOrEqual(t, math.Abs(s.noise3(x, y, z)), max3D)
+		require.LessOrEqual(t, math.Abs(s.noise4(x, y, z, w)), max4D)
+	}
+}
+
+func TestGenerator_normalized_by_max(t *testing.T) {
+	gen := New(100)
+	s := newState(100)
+
+	for i := 0.; i < 100; i++ {
+		x, y, z, w := i*0.37, i*0.11, i*0.23, i*0.07
+
+		require.Equal(t, s.noise1(x)/max1D, gen.Eval64(x))
+		require.Equal(t, s.noise2(x, y)/max2D, gen.Eval64(x, y))
+		require.Equal(t, s.noise3(x, y, z)/max3D, gen.Eval64(x, y, z))
+		require.Equal(t, s.noise4(x, y, z, w)/max4D, gen.Eval64(x, y, z, w))
+	}
+}
+
+func TestNoise_zero_at_lattice_points(t *testing.T) {
+	s := newState(100)
+
+	for i := -3.; i <= 3; i++ {
+		require.Zero(t, s.noise1(i))
+		require.Zero(t, s.noise2(i, -i))
+		require.Zero(t, s.noise3(i, -i, 2*i))
+		require.Zero(t, s.noise4(i, -i, 2*i, i+1))
+	}
+}
+
+func TestNoise_continuous_across_cells(t *testing.T) {
+	s := newState(100)
+
+	// Along the axes, the noise must be continuous across the lattice cells.
+	const eps = 1e-9
+
+	for _, c := range []float64{1, 2, 17, 255, 256} {
+		require.InDelta(t, s.noise4(c-eps, 0.3, 0.6, 0.9), s.noise4(c+eps, 0.3, 0.6, 0.9), 1e-6)
+		require.InDelta(t, s.noise4(0.3, 0.6, 0.9, c-eps), s.noise4(0.3, 0.6, 0.9, c+eps), 1e-6)
+		require.InDelta(t, s.noise3(c-eps, 0.3, 0.6), s.noise3(c+eps, 0.3, 0.6), 1e-6)
+		require.InDelta(t, s.noise2(0.3, c-eps), s.noise2(0.3, c+eps), 1e-6)
+		require.InDelta(t, s.noise1(c-eps), s.noise1(c+eps), 1e-6)
+	}
+}
+
+func TestGenerator_is_in_range(t *testing.T) {
+	gen := New(100)
+
+	minV, maxV := math.Inf(1), math.Inf(-1)
+
+	for i := 0; i < 20000; i++ {
+		f := float64(i)
+
+		for _, v := range []float64{
+			gen.Eval64(f * 0.37),
+			gen.Eval64(f*0.37, f*0.11),
+			gen.Eval64(f*0.37, f*0.11, f*0.23),
+			gen.Eval64(f*0.37, f*0.11, f*0.23, f*0.07),
+		} {
+			minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+		}
+	}
+
+	require.GreaterOrEqual(t, minV, -1., "it should be in the range of -1 to 1")
+	require.LessOrEqual(t, maxV, 1., "it should be in the range of -1 to 1")
+	require.Less(t, minV, -0.7, "it should be spread in the range")
+	require.Greater(t, maxV, 0.7, "it should be spread in the range")
+}
+
+func TestGenerator_seed(t *testing.T) {
+	gen := New(100)
+
+	before := gen.Eval64(0.5, 0.5, 0.5)
+
+	require.Equal(t, before, New(100).Eval64(0.5, 0.5, 0.5), "same seed should return the same value")
+
+	gen.Seed = 101
+
+	require.NotEqual(t, before, gen.Eval64(0.5, 0.5, 0.5), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_dimensions(t *testing.T) {
+	gen := New(100)
+
+	require.Equal(t, float32(gen.Eval64(0.1)), gen.Eval32(0.1))
+	require.Equal(t, float32(gen.Eval64(0.1, 0.2)), gen.Eval32(0.1, 0.2))
+	require.Equal(t, float32(gen.Eval64(0.1, 0.2, 0.3)), gen.Eval32(0.1, 0.2, 0.3))
+	require.Equal(t, float32(gen.Eval64(0.1, 0.2, 0.3, 0.4)), gen.Eval32(0.1, 0.2, 0.3, 0.4))
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := New(100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
+
+// maximizeCorners returns the maximum of M(p) in the cell of numDim dimensions,
+// searched by a grid and refined by the pattern search.
+func maximizeCorners(numDim int, grads [][4]float64) float64 {
+	m := func(p [4]float64) float64 {
+		var sum float64
+
+		for c := 0; c < 1<<numDim; c++ {
+			weight := 1.
+			best := math.Inf(-1)
+
+			var d [4]float64
+
+			for i := 0; i < numDim; i++ {
+				f := fade(p[i])
+				if c&(1<<i) != 0 {
+					weight *= f
+					d[i] = p[i] - 1
+				} else {
+					weight *= 1 - f
+					d[i] = p[i]
+				}
+			}
+
+			for _, g := range grads {
+				best = math.Max(best, g[0]*d[0]+g[1]*d[1]+g[2]*d[2]+g[3]*d[3])
+			}
+
+			sum += weight * best
+		}
+
+		return sum
+	}
+
+	const res = 12
+
+	var bestP [4]float64
+
+	best := math.Inf(-1)
+	numGrid := int(math.Pow(res+1, float64(numDim)))
+
+	for idx := 0; idx < numGrid; idx++ {
+		var p [4]float64
+
+		for i, k := 0, idx; i < numDim; i, k = i+1, k/(res+1) {
+			p[i] = float64(k%(res+1)) / res
+		}
+
+		if v := m(p); v > best {
+			best, bestP = v, p
+		}
+	}
+
+	numDir := int(math.Pow(3, float64(numDim)))
+
+	for step := 1. / res; step > 1e-15; {
+		improved := false
+
+		for dir := 0; dir < numDir; dir++ {
+			p := bestP
+
+			for i, k := 0, dir; i < numDim; i, k = i+1, k/3 {
+				p[i] = math.Max(0, math.Min(1, p[i]+float64(k%3-1)*step))
+			}
+
+			if v := m(p); v > best {
+				best, bestP, improved = v, p, true
+			}
+		}
+
+		if !improved {
+			step /= 2
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,251 @@
+package module
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-noise"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Add
+// ----------------------------------------------------------------------------
+
+// Add is a module which returns the sum of A and B. The value is in the range of
+// [-2, 2] if the sources are in the range of [-1, 1].
+type Add struct {
+	generator
+
+	// A and B are the sources to add.
+	A, B noise.Generator
+}
+
+// NewAdd returns a module which returns a + b.
+func NewAdd(a, b noise.Generator) *Add {
+	m := &Add{A: a, B: b}
+	m.self = m
+
+	return m
+}
+
+// eval returns A + B at the point p.
+func (m *Add) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+
+	return a + b, err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Multiply
+// ----------------------------------------------------------------------------
+
+// Multiply is a module which returns the product of A and B.
+type Multiply struct {
+	generator
+
+	// A and B are the sources to multiply.
+	A, B noise.Generator
+}
+
+// NewMultiply returns a module which returns a * b.
+func NewMultiply(a, b noise.Generator) *Multiply {
+	m := &Multiply{A: a, B: b}
+	m.self = m
+
+	return m
+}
+
+// eval returns A * B at the point p.
+func (m *Multiply) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+
+	return a * b, err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Min
+// ----------------------------------------------------------------------------
+
+// Min is a module which returns the smaller value of A and B.
+type Min struct {
+	generator
+
+	// A and B are the sources to compare.
+	A, B noise.Generator
+}
+
+// NewMin returns a module which returns the smaller value of a and b.
+func NewMin(a, b noise.Generator) *Min {
+	m := &Min{A: a, B: b}
+	m.self = m
+
+	return m
+}
+
+// eval returns the smaller value of A and B at the point p.
+func (m *Min) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+
+	return math.Min(a, b), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Max
+// ----------------------------------------------------------------------------
+
+// Max is a module which returns the larger value of A and B.
+type Max struct {
+	generator
+
+	// A and B are the sources to compare.
+	A, B noise.Generator
+}
+
+// NewMax returns a module which returns the larger value of a and b.
+func NewMax(a, b noise.Generator) *Max {
+	m := &Max{A: a, B: b}
+	m.self = m
+
+	return m
+}
+
+// eval returns the larger value of A and B at the point p.
+func (m *Max) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+
+	return math.Max(a, b), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Power
+// ----------------------------------------------------------------------------
+
+// Power is a module which raises A to the power of B.
+//
+// Unlike libnoise, the power is applied to the absolute value of A and the sign
+// of A is kept, so a negative A does not return NaN. The value may be out of
+// the range of [-1, 1] if B is negative.
+type Power struct {
+	generator
+
+	// A is the base and B is the exponent.
+	A, B noise.Generator
+}
+
+// NewPower returns a module which returns sign(a) * |a|^b.
+func NewPower(a, b noise.Generator) *Power {
+	m := &Power{A: a, B: b}
+	m.self = m
+
+	return m
+}
+
+// eval returns sign(A) * |A|^B at the point p.
+func (m *Power) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+
+	return math.Copysign(math.Pow(math.Abs(a), b), a), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Blend
+// ----------------------------------------------------------------------------
+
+// Blend is a module which interpolates between A and B by Control. It returns
+// A where Control is -1 and B where Control is 1.
+type Blend struct {
+	generator
+
+	// A and B are the sources to blend.
+	A, B noise.Generator
+	// Control is the source of the weight in the range of [-1, 1].
+	Control noise.Generator
+}
+
+// NewBlend returns a module which blends a and b by control.
+func NewBlend(a, b, control noise.Generator) *Blend {
+	m := &Blend{A: a, B: b, Control: control}
+	m.self = m
+
+	return m
+}
+
+// eval returns A and B interpolated by Control at the point p.
+func (m *Blend) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+	if err != nil {
+		return 0, err
+	}
+
+	c, err := sample(m.Control, "Control", numDim, p, strict, single)
+
+	return lerp(a, b, (c+1)/2), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Select
+// ----------------------------------------------------------------------------
+
+// Select is a module which returns B where Control is within the bounds and A
+// otherwise. The transition at the bounds is smoothed over EdgeFalloff.
+//
+// Only the selected source is evaluated, except in the transition.
+type Select struct {
+	generator
+
+	// A is the source outside the bounds.
+	A noise.Generator
+	// B is the source within the bounds.
+	B noise.Generator
+	// Control is the source to compare with the bounds.
+	Control noise.Generator
+	// LowerBound and UpperBound are the range of Control to select B.
+	LowerBound, UpperBound float64
+	// EdgeFalloff is the half width of the transition at the bounds. 0 means
+	// an abrupt transition. It is limited to the half of the bounds.
+	EdgeFalloff float64
+}
+
+// NewSelect returns a module which selects b where control is between lower and
+// upper, and a otherwise, with the smooth transition of falloff.
+func NewSelect(a, b, control noise.Generator, lower, upper, falloff float64) *Select {
+	m := &Select{
+		A:           a,
+		B:           b,
+		Control:     control,
+		LowerBound:  lower,
+		UpperBound:  upper,
+		EdgeFalloff: falloff,
+	}
+	m.self = m
+
+	return m
+}
+
+// eval returns A or B selected by Control at the point p.
+func (m *Select) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	c, err := sample(m.Control, "Control", numDim, p, strict, single)
+	if err != nil {
+		return 0, err
+	}
+
+	lower, upper := m.LowerBound, m.UpperBound
+	falloff := math.Max(0, math.Min(m.EdgeFalloff, (upper-lower)/2))
+
+	switch {
+	case c < lower-falloff, upper+falloff < c:
+		return sample(m.A, "A", numDim, p, strict, single)
+	case lower+falloff <= c && c <= upper-falloff:
+		return sample(m.B, "B", numDim, p, strict, single)
+	}
+
+	a, b, err := sample2(m.A, m.B, numDim, p, strict, single)
+	if err != nil {
+		return 0, err
+	}
+
+	if c < lower+falloff {
+		return lerp(a, b, sCurve3((c-(lower-falloff))/(2*falloff))), nil
+	}
+
+	return lerp(b, a, sCurve3((c-(upper-falloff))/(2*falloff))), nil
+}
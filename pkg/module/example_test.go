@@ -0,0 +1,84 @@
+package module_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+	"github.com/KEINOS/go-noise/pkg/module"
+)
+
+func Example() {
+	const seed = 100
+
+	src, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Mountains of ridged multifractal in the upper half.
+	ridged := fractal.New(src, seed)
+	ridged.Mode = fractal.Ridged
+	mountains := module.NewScaleBias(ridged, 0.5, 0.5)
+
+	// Low and flat plains.
+	plains := module.NewScaleBias(fractal.New(src, seed+1), 0.125, -0.75)
+
+	// Select the mountains where the low frequency control is high.
+	control := fractal.New(src, seed+2)
+	control.Frequency = 0.25
+
+	terrain := module.NewSelect(plains, mountains, control, 0, 1000, 0.125)
+
+	for x := 5.; x < 10; x++ {
+		fmt.Printf("%0.4f\n", terrain.Eval64(x, 0.5))
+	}
+
+	// Output:
+	// -0.7644
+	// -0.7291
+	// 0.3730
+	// 0.1593
+	// 0.4695
+}
+
+func ExampleNewTerrace() {
+	gen := module.NewTerrace(axisX{}, -1, 0, 1)
+
+	for _, x := range []float64{-0.75, -0.5, -0.25, 0.25, 0.5, 0.75} {
+		fmt.Printf("%0.4f\n", gen.Eval64(x))
+	}
+
+	// Output:
+	// -0.9375
+	// -0.7500
+	// -0.4375
+	// 0.0625
+	// 0.2500
+	// 0.5625
+}
+
+func ExampleNewScalePointUniform() {
+	const (
+		seed       = 100
+		smoothness = 100
+	)
+
+	src, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Same as src.Eval64(x/smoothness, y/smoothness) and moved by (10, 20).
+	gen := module.NewTranslatePoint(module.NewScalePointUniform(src, 1./smoothness), 10, 20, 0, 0)
+
+	for x := 0.; x < 3; x++ {
+		fmt.Printf("%0.4f;%0.4f\n", gen.Eval64(x, 0), src.Eval64((x+10)/smoothness, 20./smoothness))
+	}
+
+	// Output:
+	// 0.0142;0.0142
+	// 0.0282;0.0282
+	// 0.0419;0.0419
+}
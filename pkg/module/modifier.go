@@ -0,0 +1,337 @@
+package module
+
+import (
+	"math"
+	"sort"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Clamp
+// ----------------------------------------------------------------------------
+
+// Clamp is a module which limits the value of Source between LowerBound and
+// UpperBound.
+type Clamp struct {
+	generator
+
+	// Source is the source to clamp.
+	Source noise.Generator
+	// LowerBound and UpperBound are the range of the value.
+	LowerBound, UpperBound float64
+}
+
+// NewClamp returns a module which clamps src between lower and upper.
+func NewClamp(src noise.Generator, lower, upper float64) *Clamp {
+	m := &Clamp{Source: src, LowerBound: lower, UpperBound: upper}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source clamped between the bounds at the point p.
+func (m *Clamp) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+
+	return math.Max(m.LowerBound, math.Min(m.UpperBound, v)), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Abs
+// ----------------------------------------------------------------------------
+
+// Abs is a module which returns the absolute value of Source.
+type Abs struct {
+	generator
+
+	// Source is the source to take the absolute value.
+	Source noise.Generator
+}
+
+// NewAbs returns a module which returns |src|.
+func NewAbs(src noise.Generator) *Abs {
+	m := &Abs{Source: src}
+	m.self = m
+
+	return m
+}
+
+// eval returns |Source| at the point p.
+func (m *Abs) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+
+	return math.Abs(v), err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Invert
+// ----------------------------------------------------------------------------
+
+// Invert is a module which returns the negated value of Source.
+type Invert struct {
+	generator
+
+	// Source is the source to negate.
+	Source noise.Generator
+}
+
+// NewInvert returns a module which returns -src.
+func NewInvert(src noise.Generator) *Invert {
+	m := &Invert{Source: src}
+	m.self = m
+
+	return m
+}
+
+// eval returns -Source at the point p.
+func (m *Invert) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+
+	return -v, err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: ScaleBias
+// ----------------------------------------------------------------------------
+
+// ScaleBias is a module which multiplies the value of Source by Scale and adds
+// Bias.
+type ScaleBias struct {
+	generator
+
+	// Source is the source to scale.
+	Source noise.Generator
+	// Scale is the multiplier of the value.
+	Scale float64
+	// Bias is the value to add after scaling.
+	Bias float64
+}
+
+// NewScaleBias returns a module which returns src * scale + bias.
+func NewScaleBias(src noise.Generator, scale, bias float64) *ScaleBias {
+	m := &ScaleBias{Source: src, Scale: scale, Bias: bias}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source * Scale + Bias at the point p.
+func (m *ScaleBias) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+
+	return v*m.Scale + m.Bias, err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Exponent
+// ----------------------------------------------------------------------------
+
+// Exponent is a module which maps the value of Source by an exponential curve.
+// The value is normalized to [0, 1], raised to the power of Exponent and then
+// converted back to [-1, 1]. The greater the Exponent is, the more the values
+// are pushed towards -1.
+type Exponent struct {
+	generator
+
+	// Source is the source to map.
+	Source noise.Generator
+	// Exponent is the power to raise the normalized value to.
+	Exponent float64
+}
+
+// NewExponent returns a module which maps src by the curve of the exponent.
+func NewExponent(src noise.Generator, exponent float64) *Exponent {
+	m := &Exponent{Source: src, Exponent: exponent}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source mapped by the exponential curve at the point p.
+func (m *Exponent) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+
+	return math.Pow(math.Abs((v+1)/2), m.Exponent)*2 - 1, err
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Curve
+// ----------------------------------------------------------------------------
+
+// ControlPoint is a point of the mapping curve of Curve module.
+type ControlPoint struct {
+	// Input is the value of the source.
+	Input float64
+	// Output is the value to map the Input to.
+	Output float64
+}
+
+// Curve is a module which maps the value of Source by a curve. The curve passes
+// through the control points and is interpolated by the cubic interpolation
+// between them. The value beyond the control points is clamped to the first or
+// the last Output.
+type Curve struct {
+	generator
+
+	// Source is the source to map.
+	Source noise.Generator
+	// Points are the control points of the curve. They must be sorted by Input
+	// in ascending order without duplicates. At least 4 points are recommended.
+	Points []ControlPoint
+}
+
+// NewCurve returns a module which maps src by the curve through the points. The
+// points are copied and sorted by Input.
+func NewCurve(src noise.Generator, points ...ControlPoint) *Curve {
+	sorted := make([]ControlPoint, len(points))
+	copy(sorted, points)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Input < sorted[j].Input
+	})
+
+	m := &Curve{Source: src, Points: sorted}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source mapped by the curve at the point p.
+func (m *Curve) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	if len(m.Points) == 0 {
+		if strict {
+			return 0, errors.New("no control point is set to the curve")
+		}
+
+		return 0, nil
+	}
+
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+	if err != nil {
+		return 0, err
+	}
+
+	last := len(m.Points) - 1
+	i1 := sort.Search(len(m.Points), func(i int) bool { return v < m.Points[i].Input })
+
+	switch {
+	case i1 == 0:
+		return m.Points[0].Output, nil
+	case i1 > last:
+		return m.Points[last].Output, nil
+	}
+
+	i0 := i1 - 1
+	alpha := (v - m.Points[i0].Input) / (m.Points[i1].Input - m.Points[i0].Input)
+
+	return cubicInterp(
+		m.Points[maxInt(i0-1, 0)].Output,
+		m.Points[i0].Output,
+		m.Points[i1].Output,
+		m.Points[minInt(i1+1, last)].Output,
+		alpha,
+	), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Terrace
+// ----------------------------------------------------------------------------
+
+// Terrace is a module which maps the value of Source by a terrace-forming
+// curve. The value is flattened towards the lower point between the control
+// points, so the result looks like the steps of terraced fields. The value
+// beyond the control points is clamped to the first or the last point.
+type Terrace struct {
+	generator
+
+	// Source is the source to map.
+	Source noise.Generator
+	// Points are the heights of the terraces. They must be sorted in ascending
+	// order without duplicates, and at least 2 points are required.
+	Points []float64
+	// Invert flattens the value towards the upper point instead.
+	Invert bool
+}
+
+// NewTerrace returns a module which maps src by the terraces at the points. The
+// points are copied and sorted.
+func NewTerrace(src noise.Generator, points ...float64) *Terrace {
+	sorted := make([]float64, len(points))
+	copy(sorted, points)
+	sort.Float64s(sorted)
+
+	m := &Terrace{Source: src, Points: sorted}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source mapped by the terraces at the point p.
+func (m *Terrace) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	if len(m.Points) < 2 {
+		if strict {
+			return 0, errors.Errorf("at least 2 points are required for the terraces, got %d", len(m.Points))
+		}
+
+		return 0, nil
+	}
+
+	v, err := sample(m.Source, "Source", numDim, p, strict, single)
+	if err != nil {
+		return 0, err
+	}
+
+	last := len(m.Points) - 1
+	i1 := sort.SearchFloat64s(m.Points, v)
+
+	switch {
+	case i1 == 0:
+		return m.Points[0], nil
+	case i1 > last:
+		return m.Points[last], nil
+	}
+
+	v0, v1 := m.Points[i1-1], m.Points[i1]
+	alpha := (v - v0) / (v1 - v0)
+
+	if m.Invert {
+		alpha = 1 - alpha
+		v0, v1 = v1, v0
+	}
+
+	return lerp(v0, v1, alpha*alpha), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// cubicInterp returns the cubic interpolation between v1 and v2 by alpha, where
+// v0 and v3 are the neighboring values.
+func cubicInterp(v0, v1, v2, v3, alpha float64) float64 {
+	p := (v3 - v2) - (v0 - v1)
+	q := (v0 - v1) - p
+	r := v2 - v0
+
+	return p*alpha*alpha*alpha + q*alpha*alpha + r*alpha + v1
+}
+
+// minInt returns the smaller value of a and b.
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// maxInt returns the larger value of a and b.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
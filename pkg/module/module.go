@@ -0,0 +1,329 @@
+/*
+Package module is a set of composable noise modules in the style of libnoise.
+Each module combines or modifies the output of other noise generators, or
+transforms the input coordinates, and implements
+github.com/KEINOS/go-noise/noise interface itself. So they can be chained to
+build a complex graph, such as a terrain, from the built-in generators.
+
+	mountains := module.NewScaleBias(ridged, 0.5, 0.5)
+	plains := module.NewScaleBias(fbm, 0.125, -0.75)
+	terrain := module.NewSelect(plains, mountains, control, 0, 1000, 0.125)
+
+	v := terrain.Eval64(x, y)
+
+As in libnoise, the modules do not clamp their outputs. Some modules, such as
+Add, may return a value out of the range of [-1, 1] even if the sources are in
+the range. Use Clamp or ScaleBias to bring it back.
+
+The modules must be created by their constructors. The fields can be changed
+afterwards, but not while evaluating.
+
+It supports 1 to 4 dimensions.
+*/
+package module
+
+import (
+	"github.com/KEINOS/go-noise"
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// ----------------------------------------------------------------------------
+//  Type: evaluator
+// ----------------------------------------------------------------------------
+
+// evaluator is the interface of the modules to evaluate a value.
+type evaluator interface {
+	// eval returns the value of the module at the point p of numDim dimensions.
+	// If strict is true, the sources are evaluated via noise.Eval64E, or
+	// noise.Eval32E if single is true, and the first error is returned. If
+	// single is true, the sources are evaluated in float32, so they may support
+	// float32 only.
+	eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: generator
+// ----------------------------------------------------------------------------
+
+// generator implements noise.Generator interface on top of the evaluator of the
+// module which embeds it.
+type generator struct {
+	self evaluator
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 value of the module for the given coordinates. The
+// sources are evaluated in float32, so they may support float32 only.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (g *generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return g.Eval1D32(dim[0])
+	case 2:
+		return g.Eval2D32(dim[0], dim[1])
+	case 3:
+		return g.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return g.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 value of the module for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (g *generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return g.Eval1D64(dim[0])
+	case 2:
+		return g.Eval2D64(dim[0], dim[1])
+	case 3:
+		return g.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return g.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 value of the module at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (g *generator) Eval1D32(x float32) float32 {
+	v, _ := g.eval(1, [maxDim]float64{float64(x)}, false, true)
+
+	return float32(v)
+}
+
+// Eval2D32 returns a float32 value of the module at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (g *generator) Eval2D32(x, y float32) float32 {
+	v, _ := g.eval(2, [maxDim]float64{float64(x), float64(y)}, false, true)
+
+	return float32(v)
+}
+
+// Eval3D32 returns a float32 value of the module at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (g *generator) Eval3D32(x, y, z float32) float32 {
+	v, _ := g.eval(3, [maxDim]float64{float64(x), float64(y), float64(z)}, false, true)
+
+	return float32(v)
+}
+
+// Eval4D32 returns a float32 value of the module at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (g *generator) Eval4D32(x, y, z, w float32) float32 {
+	v, _ := g.eval(4, [maxDim]float64{float64(x), float64(y), float64(z), float64(w)}, false, true)
+
+	return float32(v)
+}
+
+// Eval1D64 returns a float64 value of the module at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (g *generator) Eval1D64(x float64) float64 {
+	v, _ := g.eval(1, [maxDim]float64{x}, false, false)
+
+	return v
+}
+
+// Eval2D64 returns a float64 value of the module at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (g *generator) Eval2D64(x, y float64) float64 {
+	v, _ := g.eval(2, [maxDim]float64{x, y}, false, false)
+
+	return v
+}
+
+// Eval3D64 returns a float64 value of the module at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (g *generator) Eval3D64(x, y, z float64) float64 {
+	v, _ := g.eval(3, [maxDim]float64{x, y, z}, false, false)
+
+	return v
+}
+
+// Eval4D64 returns a float64 value of the module at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (g *generator) Eval4D64(x, y, z, w float64) float64 {
+	v, _ := g.eval(4, [maxDim]float64{x, y, z, w}, false, false)
+
+	return v
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4, a source is nil or a
+// source fails to evaluate strictly.
+func (g *generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	for i, d := range dim {
+		p[i] = float64(d)
+	}
+
+	v, err := g.eval(len(dim), p, true, true)
+
+	return float32(v), err
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4, a source is nil or a
+// source fails to evaluate strictly.
+func (g *generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	copy(p[:], dim)
+
+	return g.eval(len(dim), p, true, false)
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the source instead.
+func (g *generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in Module type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the source instead.
+func (g *generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in Module type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// eval returns the value of the module which embeds g.
+func (g *generator) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	if g.self == nil {
+		if strict {
+			return 0, errors.New("the module is not initialized. Use the constructor to create it")
+		}
+
+		return 0, nil
+	}
+
+	return g.self.eval(numDim, p, strict, single)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Module supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// sample returns the value of gen at the point p of numDim dimensions. The name
+// is used in the error message. If single is true, it is evaluated in float32.
+func sample(gen noise.Generator, name string, numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	if gen == nil {
+		if strict {
+			return 0, errors.Errorf("the %s generator is not set", name)
+		}
+
+		return 0, nil
+	}
+
+	if single {
+		v, err := sample32(gen, numDim, p, strict)
+		if err != nil {
+			return 0, errors.Wrapf(err, "failed to evaluate the %s generator", name)
+		}
+
+		return v, nil
+	}
+
+	if strict {
+		// Copy to keep p on the stack in the non-strict path.
+		dim := make([]float64, numDim)
+		copy(dim, p[:numDim])
+
+		v, err := noise.Eval64E(gen, dim...)
+
+		return v, errors.Wrapf(err, "failed to evaluate the %s generator", name)
+	}
+
+	switch numDim {
+	case 1:
+		return noise.Eval1D64(gen, p[0]), nil
+	case 2:
+		return noise.Eval2D64(gen, p[0], p[1]), nil
+	case 3:
+		return noise.Eval3D64(gen, p[0], p[1], p[2]), nil
+	}
+
+	return noise.Eval4D64(gen, p[0], p[1], p[2], p[3]), nil
+}
+
+// sample32 returns the float32 value of gen at the point p of numDim dimensions
+// as float64.
+func sample32(gen noise.Generator, numDim int, p [maxDim]float64, strict bool) (float64, error) {
+	if strict {
+		dim := make([]float32, numDim)
+
+		for i := range dim {
+			dim[i] = float32(p[i])
+		}
+
+		v, err := noise.Eval32E(gen, dim...)
+
+		return float64(v), err
+	}
+
+	switch numDim {
+	case 1:
+		return float64(noise.Eval1D32(gen, float32(p[0]))), nil
+	case 2:
+		return float64(noise.Eval2D32(gen, float32(p[0]), float32(p[1]))), nil
+	case 3:
+		return float64(noise.Eval3D32(gen, float32(p[0]), float32(p[1]), float32(p[2]))), nil
+	}
+
+	return float64(noise.Eval4D32(gen, float32(p[0]), float32(p[1]), float32(p[2]), float32(p[3]))), nil
+}
+
+// sample2 returns the values of a and b at the point p of numDim dimensions.
+func sample2(a, b noise.Generator, numDim int, p [maxDim]float64, strict, single bool) (float64, float64, error) {
+	va, err := sample(a, "A", numDim, p, strict, single)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	vb, err := sample(b, "B", numDim, p, strict, single)
+
+	return va, vb, err
+}
+
+// lerp returns the linear interpolation between a and b by alpha.
+func lerp(a, b, alpha float64) float64 {
+	return a + (b-a)*alpha
+}
+
+// sCurve3 returns the cubic ease curve 3t^2-2t^3 of t in the range of [0, 1].
+func sCurve3(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
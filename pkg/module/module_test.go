@@ -0,0 +1,438 @@
+package module_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/testgen"
+	"github.com/KEINOS/go-noise/pkg/module"
+	"github.com/stretchr/testify/require"
+)
+
+// axisX is a source which returns the x coordinate as is.
+type axisX struct{}
+
+func (axisX) Eval32(dim ...float32) float32 { return dim[0] }
+func (axisX) Eval64(dim ...float64) float64 { return dim[0] }
+
+func (axisX) SetEval32(f func(seed int64, dim ...float32) float32) error { return nil }
+func (axisX) SetEval64(f func(seed int64, dim ...float64) float64) error { return nil }
+
+// squaredNorm is a source which returns the squared distance from the origin.
+type squaredNorm struct{}
+
+func (squaredNorm) Eval32(dim ...float32) float32 { return 0 }
+
+func (squaredNorm) Eval64(dim ...float64) float64 {
+	sum := 0.
+	for _, d := range dim {
+		sum += d * d
+	}
+
+	return sum
+}
+
+func (squaredNorm) SetEval32(f func(seed int64, dim ...float32) float32) error { return nil }
+func (squaredNorm) SetEval64(f func(seed int64, dim ...float64) float64) error { return nil }
+
+func newSource(t *testing.T, algo noise.Algo, seed int64) noise.Generator {
+	t.Helper()
+
+	src, err := noise.New(algo, seed)
+	require.NoError(t, err)
+
+	return src
+}
+
+func TestModules_of_constant_sources(t *testing.T) {
+	a, b := testgen.Constant(-0.5), testgen.Constant(0.25)
+
+	for _, test := range []struct {
+		name   string
+		gen    noise.Generator
+		expect float64
+	}{
+		{"Add", module.NewAdd(a, b), -0.25},
+		{"Multiply", module.NewMultiply(a, b), -0.125},
+		{"Min", module.NewMin(a, b), -0.5},
+		{"Max", module.NewMax(a, b), 0.25},
+		{"Power", module.NewPower(testgen.Constant(0.25), testgen.Constant(0.5)), 0.5},
+		{"Power negative base", module.NewPower(testgen.Constant(-0.25), testgen.Constant(0.5)), -0.5},
+		{"Blend at -1", module.NewBlend(a, b, testgen.Constant(-1)), -0.5},
+		{"Blend at 0", module.NewBlend(a, b, testgen.Constant(0)), -0.125},
+		{"Blend at 1", module.NewBlend(a, b, testgen.Constant(1)), 0.25},
+		{"Clamp lower", module.NewClamp(a, -0.25, 0.25), -0.25},
+		{"Clamp upper", module.NewClamp(testgen.Constant(0.5), -0.25, 0.25), 0.25},
+		{"Clamp within", module.NewClamp(b, -0.5, 0.5), 0.25},
+		{"Abs", module.NewAbs(a), 0.5},
+		{"Invert", module.NewInvert(a), 0.5},
+		{"ScaleBias", module.NewScaleBias(a, 0.5, 0.125), -0.125},
+		{"Exponent 1", module.NewExponent(a, 1), -0.5},
+		{"Exponent 2", module.NewExponent(b, 2), 0.625*0.625*2 - 1},
+	} {
+		require.InDelta(t, test.expect, test.gen.Eval64(0.1, 0.2), 1e-12, test.name)
+	}
+}
+
+func TestModules_compose_generators(t *testing.T) {
+	a := newSource(t, noise.OpenSimplex, 100)
+	b := newSource(t, noise.Perlin, 101)
+
+	gen := module.NewScaleBias(module.NewAdd(a, module.NewInvert(b)), 0.5, 0)
+
+	for i := 0; i < 100; i++ {
+		x, y := float64(i)*0.13, float64(i)*0.07
+
+		require.InDelta(t, (a.Eval64(x, y)-b.Eval64(x, y))/2, gen.Eval64(x, y), 1e-12)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	a, b := testgen.Constant(-1), testgen.Constant(1)
+
+	// Without falloff.
+	gen := module.NewSelect(a, b, nil, -0.5, 0.5, 0)
+
+	for _, test := range []struct {
+		control float64
+		expect  float64
+	}{
+		{-0.6, -1}, {-0.5, 1}, {0, 1}, {0.5, 1}, {0.6, -1},
+	} {
+		gen.Control = testgen.Constant(test.control)
+
+		require.Equal(t, test.expect, gen.Eval64(0.1), "control: %v", test.control)
+	}
+
+	// With falloff, the transition is smooth and symmetric around the bounds.
+	gen.EdgeFalloff = 0.1
+
+	for _, test := range []struct {
+		control float64
+		expect  float64
+	}{
+		{-0.7, -1}, {-0.6, -1}, {-0.5, 0}, {-0.4, 1}, {0, 1}, {0.4, 1}, {0.5, 0}, {0.6, -1}, {0.7, -1},
+	} {
+		gen.Control = testgen.Constant(test.control)
+
+		require.InDelta(t, test.expect, gen.Eval64(0.1), 1e-12, "control: %v", test.control)
+	}
+
+	// The falloff is limited to the half of the bounds.
+	gen.EdgeFalloff = 10
+	gen.Control = testgen.Constant(0)
+
+	require.InDelta(t, 1, gen.Eval64(0.1), 1e-12)
+}
+
+func TestSelect_evaluates_selected_source_only(t *testing.T) {
+	gen := module.NewSelect(nil, testgen.Constant(1), testgen.Constant(0), -0.5, 0.5, 0.1)
+
+	v, err := gen.Eval64E(0.1)
+
+	require.NoError(t, err, "the unselected source should not be evaluated")
+	require.Equal(t, 1., v)
+
+	gen.Control = testgen.Constant(0.5)
+
+	_, err = gen.Eval64E(0.1)
+	require.Error(t, err, "both sources should be evaluated in the transition")
+}
+
+func TestCurve(t *testing.T) {
+	points := []module.ControlPoint{
+		{Input: 1, Output: 1},
+		{Input: -1, Output: -1},
+		{Input: 0, Output: 0.5},
+		{Input: -0.5, Output: -0.5},
+	}
+
+	gen := module.NewCurve(axisX{}, points...)
+
+	require.Equal(t, -1., gen.Points[0].Input, "the points should be sorted")
+	require.Equal(t, 1., points[0].Input, "the given points should not be changed")
+
+	// The curve passes through the points and clamps beyond them.
+	for _, test := range []struct {
+		input  float64
+		expect float64
+	}{
+		{-2, -1}, {-1, -1}, {-0.5, -0.5}, {0, 0.5}, {1, 1}, {2, 1},
+	} {
+		require.InDelta(t, test.expect, gen.Eval64(test.input), 1e-12, "input: %v", test.input)
+	}
+
+	// Continuous at the points.
+	for _, point := range points {
+		require.InDelta(t, gen.Eval64(point.Input-1e-9), gen.Eval64(point.Input+1e-9), 1e-6)
+	}
+
+	// Two points make a line-like curve.
+	gen = module.NewCurve(axisX{}, module.ControlPoint{Input: -1, Output: 1}, module.ControlPoint{Input: 1, Output: -1})
+
+	require.InDelta(t, 0, gen.Eval64(0), 1e-12)
+}
+
+func TestTerrace(t *testing.T) {
+	gen := module.NewTerrace(axisX{}, 1, -1, 0)
+
+	require.Equal(t, []float64{-1, 0, 1}, gen.Points, "the points should be sorted")
+
+	for _, test := range []struct {
+		input  float64
+		expect float64
+	}{
+		{-2, -1}, {-1, -1}, {-0.5, -0.75}, {0, 0}, {0.5, 0.25}, {1, 1}, {2, 1},
+	} {
+		require.InDelta(t, test.expect, gen.Eval64(test.input), 1e-12, "input: %v", test.input)
+	}
+
+	// Inverted terraces flatten towards the upper point.
+	gen.Invert = true
+
+	require.InDelta(t, -0.25, gen.Eval64(-0.5), 1e-12)
+	require.InDelta(t, 0.75, gen.Eval64(0.5), 1e-12)
+}
+
+func TestTranslatePoint(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex, 100)
+	gen := module.NewTranslatePoint(src, 1, 2, 3, 4)
+
+	require.InDelta(t, src.Eval64(1.1), gen.Eval64(0.1), 1e-12)
+	require.InDelta(t, src.Eval64(1.1, 2.2), gen.Eval64(0.1, 0.2), 1e-12)
+	require.InDelta(t, src.Eval64(1.1, 2.2, 3.3), gen.Eval64(0.1, 0.2, 0.3), 1e-12)
+	require.InDelta(t, src.Eval64(1.1, 2.2, 3.3, 4.4), gen.Eval64(0.1, 0.2, 0.3, 0.4), 1e-12)
+}
+
+func TestScalePoint(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex, 100)
+	gen := module.NewScalePoint(src, 2, 3, 4, 5)
+
+	require.InDelta(t, src.Eval64(0.2), gen.Eval64(0.1), 1e-12)
+	require.InDelta(t, src.Eval64(0.2, 0.6), gen.Eval64(0.1, 0.2), 1e-12)
+	require.InDelta(t, src.Eval64(0.2, 0.6, 1.2), gen.Eval64(0.1, 0.2, 0.3), 1e-12)
+	require.InDelta(t, src.Eval64(0.2, 0.6, 1.2, 2), gen.Eval64(0.1, 0.2, 0.3, 0.4), 1e-12)
+
+	// Instead of dividing the coordinates by the smoothness.
+	const smoothness = 100
+
+	gen = module.NewScalePointUniform(src, 1./smoothness)
+
+	require.InDelta(t, src.Eval64(50./smoothness, 20./smoothness), gen.Eval64(50, 20), 1e-12)
+}
+
+func TestRotatePoint(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex, 100)
+
+	// No rotation evaluates the source in 3D.
+	gen := module.NewRotatePoint(src, 0, 0, 0)
+
+	require.Equal(t, src.Eval64(0.1, 0.2, 0), gen.Eval64(0.1, 0.2))
+	require.Equal(t, src.Eval64(0.1, 0, 0), gen.Eval64(0.1))
+
+	// 90 degrees around the Z axis.
+	gen.SetAngles(0, 0, 90)
+
+	x, y, z := gen.Angles()
+
+	require.Equal(t, []float64{0, 0, 90}, []float64{x, y, z})
+	require.InDelta(t, src.Eval64(0.2, -0.1, 0.3), gen.Eval64(0.1, 0.2, 0.3), 1e-12)
+	require.InDelta(t, src.Eval64(0.2, -0.1, 0.3, 0.4), gen.Eval64(0.1, 0.2, 0.3, 0.4), 1e-12, "W should not be rotated")
+
+	// Rotation keeps the distance from the origin.
+	gen = module.NewRotatePoint(squaredNorm{}, 30, 45, 60)
+
+	require.InDelta(t, 0.01+0.04+0.09, gen.Eval64(0.1, 0.2, 0.3), 1e-12)
+	require.InDelta(t, 0.01+0.04, gen.Eval64(0.1, 0.2), 1e-12)
+}
+
+func TestTurbulence(t *testing.T) {
+	const power = 0.25
+
+	gen := module.NewTurbulence(axisX{}, 100, 1, power, 3)
+
+	displaced := false
+
+	for i := 0; i < 1000; i++ {
+		x := float64(i) * 0.13
+		v := gen.Eval64(x, 0.5)
+
+		require.InDelta(t, x, v, power, "the displacement should be within the power")
+
+		displaced = displaced || v != x
+	}
+
+	require.True(t, displaced, "the coordinates should be displaced")
+
+	// Deterministic by the seed.
+	require.Equal(t, gen.Eval64(0.3, 0.5), module.NewTurbulence(axisX{}, 100, 1, power, 3).Eval64(0.3, 0.5))
+	require.NotEqual(t, gen.Eval64(0.3, 0.5), module.NewTurbulence(axisX{}, 101, 1, power, 3).Eval64(0.3, 0.5))
+
+	// No power means no displacement.
+	src := newSource(t, noise.OpenSimplex, 100)
+	gen = module.NewTurbulence(src, 100, 1, 0, 3)
+
+	require.Equal(t, src.Eval64(0.1, 0.2, 0.3), gen.Eval64(0.1, 0.2, 0.3))
+}
+
+func TestModules_are_in_range(t *testing.T) {
+	a := newSource(t, noise.OpenSimplex, 100)
+	b := newSource(t, noise.Perlin, 101)
+	c := newSource(t, noise.Value, 102)
+
+	for _, gen := range []noise.Generator{
+		module.NewMultiply(a, b),
+		module.NewMin(a, b),
+		module.NewMax(a, b),
+		module.NewPower(a, module.NewAbs(b)),
+		module.NewBlend(a, b, c),
+		module.NewSelect(a, b, c, -0.2, 0.2, 0.1),
+		module.NewAbs(a),
+		module.NewInvert(a),
+		module.NewExponent(a, 2),
+		module.NewCurve(a, module.ControlPoint{Input: -1, Output: -1}, module.ControlPoint{Input: 1, Output: 1}),
+		module.NewTerrace(a, -1, -0.2, 0.5, 1),
+		module.NewTranslatePoint(a, 1, 2, 3, 4),
+		module.NewScalePointUniform(a, 0.1),
+		module.NewRotatePoint(a, 30, 45, 60),
+		module.NewTurbulence(a, 100, 1, 1, 3),
+	} {
+		for d := 1; d <= 4; d++ {
+			for i := 0; i < 1000; i++ {
+				f := float64(i)
+				v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+				require.GreaterOrEqual(t, v, -1., "%T %dD should be in the range of -1 to 1", gen, d)
+				require.LessOrEqual(t, v, 1., "%T %dD should be in the range of -1 to 1", gen, d)
+			}
+		}
+	}
+}
+
+func TestModules_float32(t *testing.T) {
+	gen := module.NewAdd(newSource(t, noise.OpenSimplex, 100), newSource(t, noise.Perlin, 101))
+
+	require.InDelta(t, gen.Eval64(float64(float32(0.1))), gen.Eval32(0.1), 1e-6)
+	require.InDelta(t, gen.Eval64(float64(float32(0.1)), float64(float32(0.2))), gen.Eval32(0.1, 0.2), 1e-6)
+}
+
+func TestModules_EvalE(t *testing.T) {
+	gen := module.NewAdd(newSource(t, noise.OpenSimplex, 100), newSource(t, noise.Perlin, 101))
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestModules_EvalE_source_error(t *testing.T) {
+	for _, test := range []struct {
+		gen    noise.Generator
+		expect string
+	}{
+		{module.NewAdd(testgen.Constant(0), nil), "the B generator is not set"},
+		{module.NewBlend(testgen.Constant(0), testgen.Constant(0), nil), "the Control generator is not set"},
+		{module.NewCurve(testgen.Constant(0)), "no control point"},
+		{module.NewTerrace(testgen.Constant(0), 1), "at least 2 points"},
+		{&module.Add{}, "not initialized"},
+	} {
+		_, err := noise.Eval64E(test.gen, 0.1)
+
+		require.Error(t, err, "%T should be an error", test.gen)
+		require.Contains(t, err.Error(), test.expect)
+		require.Zero(t, test.gen.Eval64(0.1), "%T should return 0", test.gen)
+	}
+
+	// Custom without the user-defined function.
+	_, err := module.NewAbs(newSource(t, noise.Custom, 100)).Eval64E(0.1)
+
+	require.Error(t, err, "the error of the source should be returned")
+	require.Contains(t, err.Error(), "failed to evaluate the Source generator")
+}
+
+func TestModules_float32_only_source(t *testing.T) {
+	src32 := newSource(t, noise.Custom, 100)
+	require.NoError(t, src32.SetEval32(func(seed int64, dim ...float32) float32 {
+		return float32(math.Sin(float64(dim[0])))
+	}))
+
+	src64 := newSource(t, noise.Custom, 100)
+	require.NoError(t, src64.SetEval64(func(seed int64, dim ...float64) float64 {
+		return math.Sin(dim[0])
+	}))
+
+	// The leaves of the graph, including the control and the displacement.
+	graph := func(src noise.Generator) noise.Generator {
+		sel := module.NewSelect(src, module.NewScaleBias(src, 0.5, 0.25), module.NewRotatePoint(src, 30, 45, 60), -0.2, 0.2, 0.1)
+
+		return module.NewTurbulence(module.NewScalePointUniform(sel, 0.5), 100, 1, 1, 3)
+	}
+
+	gen32, gen64 := graph(src32), graph(src64)
+
+	require.NotPanics(t, func() {
+		gen32.Eval32(0.1)
+		gen32.Eval32(0.1, 0.2)
+		gen32.Eval32(0.1, 0.2, 0.3)
+		gen32.Eval32(0.1, 0.2, 0.3, 0.4)
+	})
+
+	v, err := noise.Eval32E(gen32, 0.1, 0.2, 0.3)
+
+	require.NoError(t, err)
+	require.Equal(t, gen32.Eval32(0.1, 0.2, 0.3), v)
+	require.InDelta(t, gen64.Eval64(0.1, 0.2, 0.3), gen32.Eval32(0.1, 0.2, 0.3), 1e-5)
+}
+
+func TestModules_fixed_arity_zero_allocation(t *testing.T) {
+	a := newSource(t, noise.OpenSimplex, 100)
+	b := newSource(t, noise.Perlin, 101)
+
+	gen := module.NewTerrace(
+		module.NewSelect(a, module.NewScaleBias(b, 0.5, 0.25), module.NewRotatePoint(a, 30, 45, 60), -0.2, 0.2, 0.1),
+		-1, 0, 1,
+	)
+	gen.Source = module.NewTurbulence(module.NewScalePointUniform(gen.Source, 0.5), 100, 1, 1, 3)
+
+	// Build the cached state before measuring.
+	_ = gen.Eval64(0)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+		_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+	}))
+}
+
+func TestModules_SetEval(t *testing.T) {
+	gen := module.NewAbs(testgen.Constant(0))
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
+
+func TestPower_keeps_sign(t *testing.T) {
+	gen := module.NewPower(testgen.Constant(-0.5), testgen.Constant(3))
+
+	require.False(t, math.IsNaN(gen.Eval64(0.1)))
+	require.InDelta(t, -0.125, gen.Eval64(0.1), 1e-12)
+}
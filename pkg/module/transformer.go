@@ -0,0 +1,220 @@
+package module
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/displace"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+	"github.com/KEINOS/go-noise/pkg/improvedperlin"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: TranslatePoint
+// ----------------------------------------------------------------------------
+
+// TranslatePoint is a module which moves the input coordinates before
+// evaluating Source. The axes beyond the number of the coordinates are ignored.
+type TranslatePoint struct {
+	generator
+
+	// Source is the source to evaluate at the moved coordinates.
+	Source noise.Generator
+	// X, Y, Z and W are the amounts to add to each axis.
+	X, Y, Z, W float64
+}
+
+// NewTranslatePoint returns a module which evaluates src at (x+dx, y+dy, z+dz,
+// w+dw).
+func NewTranslatePoint(src noise.Generator, dx, dy, dz, dw float64) *TranslatePoint {
+	m := &TranslatePoint{Source: src, X: dx, Y: dy, Z: dz, W: dw}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source at the point p moved by the translation.
+func (m *TranslatePoint) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	p[0] += m.X
+	p[1] += m.Y
+	p[2] += m.Z
+	p[3] += m.W
+
+	return sample(m.Source, "Source", numDim, p, strict, single)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: ScalePoint
+// ----------------------------------------------------------------------------
+
+// ScalePoint is a module which multiplies the input coordinates before
+// evaluating Source. The smaller the scale is, the smoother the noise is. The
+// axes beyond the number of the coordinates are ignored.
+type ScalePoint struct {
+	generator
+
+	// Source is the source to evaluate at the scaled coordinates.
+	Source noise.Generator
+	// X, Y, Z and W are the multipliers of each axis.
+	X, Y, Z, W float64
+}
+
+// NewScalePoint returns a module which evaluates src at (x*sx, y*sy, z*sz,
+// w*sw).
+func NewScalePoint(src noise.Generator, sx, sy, sz, sw float64) *ScalePoint {
+	m := &ScalePoint{Source: src, X: sx, Y: sy, Z: sz, W: sw}
+	m.self = m
+
+	return m
+}
+
+// NewScalePointUniform returns a module which evaluates src at the coordinates
+// multiplied by scale in all the axes. For example, 1/smoothness.
+func NewScalePointUniform(src noise.Generator, scale float64) *ScalePoint {
+	return NewScalePoint(src, scale, scale, scale, scale)
+}
+
+// eval returns Source at the point p scaled by the multipliers.
+func (m *ScalePoint) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	p[0] *= m.X
+	p[1] *= m.Y
+	p[2] *= m.Z
+	p[3] *= m.W
+
+	return sample(m.Source, "Source", numDim, p, strict, single)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: RotatePoint
+// ----------------------------------------------------------------------------
+
+// RotatePoint is a module which rotates the input coordinates around the
+// origin before evaluating Source. The rotation is given by the Euler angles in
+// degrees, in the same way as libnoise.
+//
+// Since the rotation is 3-dimensional, 1 and 2 dimensional coordinates are
+// extended to 3 dimensions with the missing axes of 0 and Source is evaluated
+// in 3 dimensions. The W axis of 4 dimensional coordinates is not rotated.
+type RotatePoint struct {
+	generator
+
+	// Source is the source to evaluate at the rotated coordinates.
+	Source noise.Generator
+
+	angles [3]float64
+	matrix [3][3]float64
+}
+
+// NewRotatePoint returns a module which evaluates src at the coordinates
+// rotated by the angles in degrees around the X, Y and Z axes.
+func NewRotatePoint(src noise.Generator, xAngle, yAngle, zAngle float64) *RotatePoint {
+	m := &RotatePoint{Source: src}
+	m.self = m
+
+	m.SetAngles(xAngle, yAngle, zAngle)
+
+	return m
+}
+
+// Angles returns the rotation angles in degrees around the X, Y and Z axes.
+func (m *RotatePoint) Angles() (xAngle, yAngle, zAngle float64) {
+	return m.angles[0], m.angles[1], m.angles[2]
+}
+
+// SetAngles sets the rotation angles in degrees around the X, Y and Z axes.
+func (m *RotatePoint) SetAngles(xAngle, yAngle, zAngle float64) {
+	const degToRad = math.Pi / 180
+
+	xSin, xCos := math.Sincos(xAngle * degToRad)
+	ySin, yCos := math.Sincos(yAngle * degToRad)
+	zSin, zCos := math.Sincos(zAngle * degToRad)
+
+	m.angles = [3]float64{xAngle, yAngle, zAngle}
+	m.matrix = [3][3]float64{
+		{ySin*xSin*zSin + yCos*zCos, xCos * zSin, ySin*zCos - yCos*xSin*zSin},
+		{ySin*xSin*zCos - yCos*zSin, xCos * zCos, -yCos*xSin*zCos - ySin*zSin},
+		{-ySin * xCos, xSin, yCos * xCos},
+	}
+}
+
+// eval returns Source at the point p rotated by the angles.
+func (m *RotatePoint) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	var q [maxDim]float64
+
+	for i, row := range m.matrix {
+		q[i] = row[0]*p[0] + row[1]*p[1] + row[2]*p[2]
+	}
+
+	q[3] = p[3]
+
+	if numDim < 3 {
+		numDim = 3
+	}
+
+	return sample(m.Source, "Source", numDim, q, strict, single)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Turbulence
+// ----------------------------------------------------------------------------
+
+// Turbulence is a module which displaces the input coordinates randomly before
+// evaluating Source. Each axis is displaced by the value of Displacement at a
+// shifted point, so the axes are not correlated.
+type Turbulence struct {
+	generator
+
+	// Source is the source to evaluate at the displaced coordinates.
+	Source noise.Generator
+	// Displacement is the source of the displacement. NewTurbulence sets the
+	// fBm of improved Perlin noise.
+	Displacement noise.Generator
+	// Frequency is the multiplier of the coordinates to evaluate Displacement.
+	// The greater it is, the more frequently the displacement changes.
+	Frequency float64
+	// Power is the multiplier of the displacement. 0 means no displacement.
+	Power float64
+}
+
+// NewTurbulence returns a module which displaces the coordinates of src. The
+// displacement is the fBm of improved Perlin noise of the seed, with the
+// roughness as the number of octaves.
+//
+// The values of libnoise's default are the frequency of 1, the power of 1 and
+// the roughness of 3.
+func NewTurbulence(src noise.Generator, seed int64, frequency, power float64, roughness int) *Turbulence {
+	displacement := fractal.New(improvedperlin.New(seed), seed)
+	displacement.Octaves = roughness
+
+	m := &Turbulence{
+		Source:       src,
+		Displacement: displacement,
+		Frequency:    frequency,
+		Power:        power,
+	}
+	m.self = m
+
+	return m
+}
+
+// eval returns Source at the point p displaced by Displacement.
+func (m *Turbulence) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	var displaced [maxDim]float64
+
+	for axis := 0; axis < numDim; axis++ {
+		var q [maxDim]float64
+
+		for i := 0; i < numDim; i++ {
+			q[i] = p[i]*m.Frequency + displace.Offsets[axis][i]
+		}
+
+		d, err := sample(m.Displacement, "Displacement", numDim, q, strict, single)
+		if err != nil {
+			return 0, err
+		}
+
+		displaced[axis] = p[axis] + d*m.Power
+	}
+
+	return sample(m.Source, "Source", numDim, displaced, strict, single)
+}
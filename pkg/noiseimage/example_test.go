@@ -0,0 +1,36 @@
+package noiseimage_test
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
+)
+
+func ExampleNewGray() {
+	const seed = 100
+
+	gen, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// 250x250 pixels of the noise from (0, 0) to (10, 10). The image can be
+	// passed to png.Encode or draw.Draw as is.
+	img := noiseimage.NewGray(gen, image.Rect(0, 0, 250, 250), noiseimage.Viewport{
+		Scale: 1. / 25,
+	})
+
+	for x := 0; x < 250; x += 50 {
+		fmt.Println(x, img.GrayAt(x, 100).Y)
+	}
+
+	// Output:
+	// 0 191
+	// 50 180
+	// 100 98
+	// 150 104
+	// 200 207
+}
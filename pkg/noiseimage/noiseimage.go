@@ -0,0 +1,251 @@
+/*
+Package noiseimage provides the adapters of noise generators to image.Image
+interface. The pixels are evaluated lazily when they are read, so the images can
+be passed directly to png.Encode, draw.Draw or any other image library without
+the pixel loop.
+
+The Viewport maps the pixel coordinates to the noise coordinates. The noise
+value between -1 and 1 is mapped to the darkest and the brightest gray of Gray
+and Gray16, or to the color of the color map of RGBA.
+
+	img := noiseimage.NewGray(gen, image.Rect(0, 0, 256, 256), noiseimage.Viewport{
+		Scale: 1. / 32,
+	})
+
+	err := png.Encode(w, img)
+*/
+package noiseimage
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/KEINOS/go-noise"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Viewport
+// ----------------------------------------------------------------------------
+
+// Viewport maps the pixel (x, y) to the noise coordinates of
+// (X + x*Scale, Y + y*Scale).
+type Viewport struct {
+	// X is the x-axis coordinate of the pixel at (0, 0).
+	X float64
+	// Y is the y-axis coordinate of the pixel at (0, 0).
+	Y float64
+	// Z is the z-axis coordinate of the slice. Ignored unless UseZ is true.
+	Z float64
+	// Scale is the distance between the pixels in the noise coordinates. The
+	// smaller the value, the more zoomed in.
+	Scale float64
+	// UseZ evaluates the 3D noise at the slice of Z. Otherwise it evaluates the
+	// 2D noise.
+	UseZ bool
+}
+
+// At returns the noise coordinates of the pixel at (x, y).
+func (v Viewport) At(x, y int) (float64, float64) {
+	return v.X + float64(x)*v.Scale, v.Y + float64(y)*v.Scale
+}
+
+// Eval returns the noise value of src at the pixel (x, y).
+func (v Viewport) Eval(src noise.Generator, x, y int) float64 {
+	xx, yy := v.At(x, y)
+
+	if v.UseZ {
+		return noise.Eval3D64(src, xx, yy, v.Z)
+	}
+
+	return noise.Eval2D64(src, xx, yy)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Gray
+// ----------------------------------------------------------------------------
+
+// NewGray returns an 8-bit gray scale image of src in the bounds of rect.
+func NewGray(src noise.Generator, rect image.Rectangle, vp Viewport) *Gray {
+	return &Gray{
+		Source:   src,
+		Rect:     rect,
+		Viewport: vp,
+	}
+}
+
+// Gray is an 8-bit gray scale image of a noise generator. It implements
+// image.Image interface.
+type Gray struct {
+	// Source is the noise generator to draw. It must not be nil.
+	Source noise.Generator
+	// Rect is the bounds of the image.
+	Rect image.Rectangle
+	// Viewport maps the pixels to the noise coordinates.
+	Viewport Viewport
+}
+
+// At implements image.Image interface.
+func (p *Gray) At(x, y int) color.Color {
+	return p.GrayAt(x, y)
+}
+
+// Bounds implements image.Image interface.
+func (p *Gray) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+// ColorModel implements image.Image interface.
+func (p *Gray) ColorModel() color.Model {
+	return color.GrayModel
+}
+
+// GrayAt returns the color of the pixel at (x, y). It returns the zero color if
+// the pixel is out of the bounds.
+func (p *Gray) GrayAt(x, y int) color.Gray {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return color.Gray{}
+	}
+
+	return color.Gray{Y: uint8(toUnit(p.Viewport.Eval(p.Source, x, y))*0xff + .5)}
+}
+
+// Opaque returns true since all the pixels are opaque.
+func (p *Gray) Opaque() bool {
+	return true
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Gray16
+// ----------------------------------------------------------------------------
+
+// NewGray16 returns a 16-bit gray scale image of src in the bounds of rect.
+func NewGray16(src noise.Generator, rect image.Rectangle, vp Viewport) *Gray16 {
+	return &Gray16{
+		Source:   src,
+		Rect:     rect,
+		Viewport: vp,
+	}
+}
+
+// Gray16 is a 16-bit gray scale image of a noise generator. It implements
+// image.Image interface.
+type Gray16 struct {
+	// Source is the noise generator to draw. It must not be nil.
+	Source noise.Generator
+	// Rect is the bounds of the image.
+	Rect image.Rectangle
+	// Viewport maps the pixels to the noise coordinates.
+	Viewport Viewport
+}
+
+// At implements image.Image interface.
+func (p *Gray16) At(x, y int) color.Color {
+	return p.Gray16At(x, y)
+}
+
+// Bounds implements image.Image interface.
+func (p *Gray16) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+// ColorModel implements image.Image interface.
+func (p *Gray16) ColorModel() color.Model {
+	return color.Gray16Model
+}
+
+// Gray16At returns the color of the pixel at (x, y). It returns the zero color
+// if the pixel is out of the bounds.
+func (p *Gray16) Gray16At(x, y int) color.Gray16 {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return color.Gray16{}
+	}
+
+	return color.Gray16{Y: uint16(toUnit(p.Viewport.Eval(p.Source, x, y))*0xffff + .5)}
+}
+
+// Opaque returns true since all the pixels are opaque.
+func (p *Gray16) Opaque() bool {
+	return true
+}
+
+// ----------------------------------------------------------------------------
+//  Type: RGBA
+// ----------------------------------------------------------------------------
+
+// NewRGBA returns a colored image of src in the bounds of rect. The noise values
+// are colored by colorMap.
+func NewRGBA(src noise.Generator, rect image.Rectangle, vp Viewport, colorMap func(v float64) color.RGBA) *RGBA {
+	return &RGBA{
+		Source:   src,
+		Rect:     rect,
+		Viewport: vp,
+		ColorMap: colorMap,
+	}
+}
+
+// RGBA is a colored image of a noise generator. It implements image.Image
+// interface.
+type RGBA struct {
+	// Source is the noise generator to draw. It must not be nil.
+	Source noise.Generator
+	// ColorMap returns the color of the noise value v, which is usually between
+	// -1 and 1. If nil, the value is drawn in the gray scale.
+	ColorMap func(v float64) color.RGBA
+	// Rect is the bounds of the image.
+	Rect image.Rectangle
+	// Viewport maps the pixels to the noise coordinates.
+	Viewport Viewport
+}
+
+// At implements image.Image interface.
+func (p *RGBA) At(x, y int) color.Color {
+	return p.RGBAAt(x, y)
+}
+
+// Bounds implements image.Image interface.
+func (p *RGBA) Bounds() image.Rectangle {
+	return p.Rect
+}
+
+// ColorModel implements image.Image interface.
+func (p *RGBA) ColorModel() color.Model {
+	return color.RGBAModel
+}
+
+// RGBAAt returns the color of the pixel at (x, y). It returns the zero color if
+// the pixel is out of the bounds.
+func (p *RGBA) RGBAAt(x, y int) color.RGBA {
+	if !(image.Point{X: x, Y: y}.In(p.Rect)) {
+		return color.RGBA{}
+	}
+
+	v := p.Viewport.Eval(p.Source, x, y)
+
+	if p.ColorMap == nil {
+		c := uint8(toUnit(v)*0xff + .5)
+
+		return color.RGBA{R: c, G: c, B: c, A: 0xff}
+	}
+
+	return p.ColorMap(v)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// toUnit maps the noise value v between -1 and 1 to the range of [0, 1]. The
+// values out of the range are clamped and NaN is mapped to 0.
+func toUnit(v float64) float64 {
+	u := (v + 1) / 2
+
+	switch {
+	case math.IsNaN(u), u < 0:
+		return 0
+	case u > 1:
+		return 1
+	}
+
+	return u
+}
@@ -0,0 +1,154 @@
+package noiseimage_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/testgen"
+	"github.com/KEINOS/go-noise/pkg/noiseimage"
+	"github.com/stretchr/testify/require"
+)
+
+func newSource(t *testing.T) noise.Generator {
+	t.Helper()
+
+	src, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	return src
+}
+
+func TestViewport_Eval(t *testing.T) {
+	src := newSource(t)
+	vp := noiseimage.Viewport{X: 1.5, Y: -2, Z: 0.75, Scale: 0.125}
+
+	x, y := vp.At(4, 8)
+	require.Equal(t, 2., x)
+	require.Equal(t, -1., y)
+
+	require.Equal(t, src.Eval64(2, -1), vp.Eval(src, 4, 8),
+		"it should evaluate 2D noise by default")
+
+	vp.UseZ = true
+
+	require.Equal(t, src.Eval64(2, -1, 0.75), vp.Eval(src, 4, 8),
+		"it should evaluate 3D noise at the slice of Z")
+}
+
+func TestGray(t *testing.T) {
+	rect := image.Rect(-2, -3, 5, 4)
+	img := noiseimage.NewGray(newSource(t), rect, noiseimage.Viewport{Scale: 0.1})
+
+	require.Equal(t, rect, img.Bounds())
+	require.Equal(t, color.GrayModel, img.ColorModel())
+	require.True(t, img.Opaque())
+
+	require.Equal(t, color.Gray{}, img.GrayAt(5, 0), "out of the bounds should be zero")
+	require.Equal(t, img.GrayAt(1, 2), img.At(1, 2))
+
+	// The gray of the noise values of -1, 0, 1 and out of the range.
+	for v, want := range map[float64]uint8{-1: 0, 0: 128, 1: 255, -3: 0, 3: 255} {
+		img := noiseimage.NewGray(testgen.Constant(v), rect, noiseimage.Viewport{})
+
+		require.Equal(t, want, img.GrayAt(0, 0).Y, "noise value: %v", v)
+	}
+}
+
+func TestGray16(t *testing.T) {
+	rect := image.Rect(0, 0, 4, 4)
+	img := noiseimage.NewGray16(newSource(t), rect, noiseimage.Viewport{Scale: 0.1})
+
+	require.Equal(t, rect, img.Bounds())
+	require.Equal(t, color.Gray16Model, img.ColorModel())
+	require.True(t, img.Opaque())
+
+	require.Equal(t, color.Gray16{}, img.Gray16At(-1, 0), "out of the bounds should be zero")
+	require.Equal(t, img.Gray16At(1, 2), img.At(1, 2))
+
+	for v, want := range map[float64]uint16{-1: 0, 0: 32768, 1: 65535, -3: 0, 3: 65535} {
+		img := noiseimage.NewGray16(testgen.Constant(v), rect, noiseimage.Viewport{})
+
+		require.Equal(t, want, img.Gray16At(0, 0).Y, "noise value: %v", v)
+	}
+}
+
+func TestRGBA(t *testing.T) {
+	src := newSource(t)
+	rect := image.Rect(0, 0, 4, 4)
+	vp := noiseimage.Viewport{Scale: 0.1}
+
+	colorMap := func(v float64) color.RGBA {
+		if v < 0 {
+			return color.RGBA{B: 0xff, A: 0xff}
+		}
+
+		return color.RGBA{G: 0xff, A: 0xff}
+	}
+
+	img := noiseimage.NewRGBA(src, rect, vp, colorMap)
+
+	require.Equal(t, rect, img.Bounds())
+	require.Equal(t, color.RGBAModel, img.ColorModel())
+	require.Equal(t, color.RGBA{}, img.RGBAAt(0, 4), "out of the bounds should be zero")
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			require.Equal(t, colorMap(vp.Eval(src, x, y)), img.RGBAAt(x, y))
+			require.Equal(t, img.RGBAAt(x, y), img.At(x, y))
+		}
+	}
+
+	// Without the color map it should be the same as Gray.
+	img.ColorMap = nil
+	gray := noiseimage.NewGray(src, rect, vp)
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			g := gray.GrayAt(x, y).Y
+
+			require.Equal(t, color.RGBA{R: g, G: g, B: g, A: 0xff}, img.RGBAAt(x, y))
+		}
+	}
+}
+
+func TestGray16_png_round_trip(t *testing.T) {
+	img := noiseimage.NewGray16(newSource(t), image.Rect(0, 0, 16, 8), noiseimage.Viewport{
+		X: 0.5, Scale: 0.1, Z: 0.3, UseZ: true,
+	})
+
+	var buf bytes.Buffer
+
+	require.NoError(t, png.Encode(&buf, img))
+
+	decoded, err := png.Decode(&buf)
+	require.NoError(t, err)
+
+	gray, ok := decoded.(*image.Gray16)
+	require.True(t, ok, "it should be encoded as 16-bit gray scale PNG")
+	require.Equal(t, img.Bounds(), gray.Bounds())
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 16; x++ {
+			require.Equal(t, img.Gray16At(x, y), gray.Gray16At(x, y))
+		}
+	}
+}
+
+func TestGray_draw(t *testing.T) {
+	img := noiseimage.NewGray(newSource(t), image.Rect(0, 0, 8, 8), noiseimage.Viewport{Scale: 0.1})
+
+	// Draw the bottom right quarter of the noise image to the canvas.
+	dst := image.NewGray(image.Rect(0, 0, 4, 4))
+	draw.Draw(dst, dst.Bounds(), img, image.Point{X: 4, Y: 4}, draw.Src)
+
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			require.Equal(t, img.GrayAt(x+4, y+4), dst.GrayAt(x, y))
+		}
+	}
+}
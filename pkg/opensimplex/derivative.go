@@ -0,0 +1,821 @@
+package opensimplex
+
+import "math"
+
+// The constants of opensimplex-go.
+const (
+	stretchConstant2D = -0.211324865405187 // (1/Math.sqrt(2+1)-1)/2
+	squishConstant2D  = 0.366025403784439  // (Math.sqrt(2+1)-1)/2
+	stretchConstant3D = -1.0 / 6           // (1/Math.sqrt(3+1)-1)/3
+	squishConstant3D  = 1.0 / 3            // (Math.sqrt(3+1)-1)/3
+
+	normConstant2D = 47
+	normConstant3D = 103
+)
+
+// gradients2D are the gradients of 2D of opensimplex-go.
+var gradients2D = [...]float64{
+	5, 2, 2, 5,
+	-5, 2, -2, 5,
+	5, -2, 2, -5,
+	-5, -2, -2, -5,
+}
+
+// gradients3D are the gradients of 3D of opensimplex-go.
+var gradients3D = [...]float64{
+	-11, 4, 4, -4, 11, 4, -4, 4, 11,
+	11, 4, 4, 4, 11, 4, 4, 4, 11,
+	-11, -4, 4, -4, -11, 4, -4, -4, 11,
+	11, -4, 4, 4, -11, 4, 4, -4, 11,
+	-11, 4, -4, -4, 11, -4, -4, 4, -11,
+	11, 4, -4, 4, 11, -4, 4, 4, -11,
+	-11, -4, -4, -4, -11, -4, -4, -4, -11,
+	11, -4, -4, 4, -11, -4, 4, -4, -11,
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// EvalDeriv2D32 returns a float32 OpenSimplex noise value at (x, y) and its
+// partial derivatives. It is a conversion of float64 to float32.
+//
+// Note that the value comes from the float64 evaluation of EvalDeriv2D64, not
+// from the float32 one of Eval2D32. So it may differ from Eval2D32(x, y) by the
+// rounding errors of float32, up to about 1e-6.
+//
+// See EvalDeriv2D64 for the details.
+func (n *Generator) EvalDeriv2D32(x, y float32) (v, dx, dy float32) {
+	v64, dx64, dy64 := n.EvalDeriv2D64(float64(x), float64(y))
+
+	return float32(v64), float32(dx64), float32(dy64)
+}
+
+// EvalDeriv2D64 returns a float64 OpenSimplex noise value at (x, y) and its
+// partial derivatives along the x and y axes. The value is equal to the one of
+// Eval64(x, y) and the derivatives are computed analytically.
+func (n *Generator) EvalDeriv2D64(x, y float64) (v, dx, dy float64) {
+	return n.state().perm.eval2Deriv(x, y)
+}
+
+// EvalDeriv3D32 returns a float32 OpenSimplex noise value at (x, y, z) and its
+// partial derivatives. It is a conversion of float64 to float32.
+//
+// Note that the value comes from the float64 evaluation of EvalDeriv3D64, not
+// from the float32 one of Eval3D32. So it may differ from Eval3D32(x, y, z) by
+// the rounding errors of float32, up to about 1e-6.
+//
+// See EvalDeriv3D64 for the details.
+func (n *Generator) EvalDeriv3D32(x, y, z float32) (v, dx, dy, dz float32) {
+	v64, dx64, dy64, dz64 := n.EvalDeriv3D64(float64(x), float64(y), float64(z))
+
+	return float32(v64), float32(dx64), float32(dy64), float32(dz64)
+}
+
+// EvalDeriv3D64 returns a float64 OpenSimplex noise value at (x, y, z) and its
+// partial derivatives along the x, y and z axes. The value is equal to the one
+// of Eval64(x, y, z) and the derivatives are computed analytically.
+func (n *Generator) EvalDeriv3D64(x, y, z float64) (v, dx, dy, dz float64) {
+	return n.state().perm.eval3Deriv(x, y, z)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: permutation
+// ----------------------------------------------------------------------------
+
+// permutation holds the permutation tables of opensimplex-go. Since they are
+// not exported, they are rebuilt in the same manner as opensimplex-go does.
+type permutation struct {
+	perm            [256]int16
+	permGradIndex3D [256]int16
+}
+
+// newPermutation returns the seeded permutation tables of opensimplex-go.
+func newPermutation(seed int64) *permutation {
+	p := new(permutation)
+
+	var source [256]int16
+
+	for i := range source {
+		source[i] = int16(i)
+	}
+
+	seed = seed*6364136223846793005 + 1442695040888963407
+	seed = seed*6364136223846793005 + 1442695040888963407
+	seed = seed*6364136223846793005 + 1442695040888963407
+
+	for i := int32(255); i >= 0; i-- {
+		seed = seed*6364136223846793005 + 1442695040888963407
+
+		r := int32((seed + 31) % int64(i+1))
+		if r < 0 {
+			r += i + 1
+		}
+
+		p.perm[i] = source[r]
+		p.permGradIndex3D[i] = (p.perm[i] % (int16(len(gradients3D)) / 3)) * 3
+		source[r] = source[i]
+	}
+
+	return p
+}
+
+// eval2Deriv returns the 2D noise value and its gradient. It is a port of
+// Eval2 of opensimplex-go which differentiates each kernel of the lattice
+// vertices, so the value is exactly the same as the original.
+func (p *permutation) eval2Deriv(x, y float64) (float64, float64, float64) {
+	// Place input coordinates onto grid.
+	stretchOffset := (x + y) * stretchConstant2D
+	xs := x + stretchOffset
+	ys := y + stretchOffset
+
+	// Floor to get grid coordinates of rhombus (stretched square) super-cell origin.
+	xsb := int32(math.Floor(xs))
+	ysb := int32(math.Floor(ys))
+
+	// Skew out to get actual coordinates of rhombus origin. We'll need these later.
+	squishOffset := float64(xsb+ysb) * squishConstant2D
+	xb := float64(xsb) + squishOffset
+	yb := float64(ysb) + squishOffset
+
+	// Compute grid coordinates relative to rhombus origin.
+	xins := xs - float64(xsb)
+	yins := ys - float64(ysb)
+
+	// Sum those together to get a value that determines which region we're in.
+	inSum := xins + yins
+
+	// Positions relative to origin point.
+	dx0 := x - xb
+	dy0 := y - yb
+
+	// We'll be defining these inside the next block and using them afterwards.
+	var dxExt, dyExt float64
+	var xsvExt, ysvExt int32
+
+	var k kernel2D
+
+	// Contribution (1,0)
+	dx1 := dx0 - 1 - squishConstant2D
+	dy1 := dy0 - 0 - squishConstant2D
+	k.add(p, xsb+1, ysb+0, dx1, dy1)
+
+	// Contribution (0,1)
+	dx2 := dx0 - 0 - squishConstant2D
+	dy2 := dy0 - 1 - squishConstant2D
+	k.add(p, xsb+0, ysb+1, dx2, dy2)
+
+	if inSum <= 1 { // We're inside the triangle (2-Simplex) at (0,0)
+		zins := 1 - inSum
+		if zins > xins || zins > yins { // (0,0) is one of the closest two triangular vertices
+			if xins > yins {
+				xsvExt = xsb + 1
+				ysvExt = ysb - 1
+				dxExt = dx0 - 1
+				dyExt = dy0 + 1
+			} else {
+				xsvExt = xsb - 1
+				ysvExt = ysb + 1
+				dxExt = dx0 + 1
+				dyExt = dy0 - 1
+			}
+		} else { // (1,0) and (0,1) are the closest two vertices.
+			xsvExt = xsb + 1
+			ysvExt = ysb + 1
+			dxExt = dx0 - 1 - 2*squishConstant2D
+			dyExt = dy0 - 1 - 2*squishConstant2D
+		}
+	} else { // We're inside the triangle (2-Simplex) at (1,1)
+		zins := 2 - inSum
+		if zins < xins || zins < yins { // (0,0) is one of the closest two triangular vertices
+			if xins > yins {
+				xsvExt = xsb + 2
+				ysvExt = ysb + 0
+				dxExt = dx0 - 2 - 2*squishConstant2D
+				dyExt = dy0 + 0 - 2*squishConstant2D
+			} else {
+				xsvExt = xsb + 0
+				ysvExt = ysb + 2
+				dxExt = dx0 + 0 - 2*squishConstant2D
+				dyExt = dy0 - 2 - 2*squishConstant2D
+			}
+		} else { // (1,0) and (0,1) are the closest two vertices.
+			dxExt = dx0
+			dyExt = dy0
+			xsvExt = xsb
+			ysvExt = ysb
+		}
+		xsb += 1
+		ysb += 1
+		dx0 = dx0 - 1 - 2*squishConstant2D
+		dy0 = dy0 - 1 - 2*squishConstant2D
+	}
+
+	// Contribution (0,0) or (1,1)
+	k.add(p, xsb, ysb, dx0, dy0)
+
+	// Extra Vertex
+	k.add(p, xsvExt, ysvExt, dxExt, dyExt)
+
+	return k.result()
+}
+
+// eval3Deriv returns the 3D noise value and its gradient. It is a port of
+// Eval3 of opensimplex-go which differentiates each kernel of the lattice
+// vertices, so the value is exactly the same as the original.
+//
+// Note that the original does not sum up all the vertices in the reach of the
+// kernel, so the value has tiny discontinuities at some boundaries of the
+// regions. It is ported as is to keep the value the same.
+func (p *permutation) eval3Deriv(x, y, z float64) (float64, float64, float64, float64) {
+	// Place input coordinates on simplectic honeycomb.
+	stretchOffset := (x + y + z) * stretchConstant3D
+	xs := x + stretchOffset
+	ys := y + stretchOffset
+	zs := z + stretchOffset
+
+	// Floor to get simplectic honeycomb coordinates of rhombohedron (stretched cube) super-cell origin.
+	xsb := int32(math.Floor(xs))
+	ysb := int32(math.Floor(ys))
+	zsb := int32(math.Floor(zs))
+
+	// Skew out to get actual coordinates of rhombohedron origin. We'll need these later.
+	squishOffset := float64(xsb+ysb+zsb) * squishConstant3D
+	xb := float64(xsb) + squishOffset
+	yb := float64(ysb) + squishOffset
+	zb := float64(zsb) + squishOffset
+
+	// Compute simplectic honeycomb coordinates relative to rhombohedral origin.
+	xins := xs - float64(xsb)
+	yins := ys - float64(ysb)
+	zins := zs - float64(zsb)
+
+	// Sum those together to get a value that determines which region we're in.
+	inSum := xins + yins + zins
+
+	// Positions relative to origin point.
+	dx0 := x - xb
+	dy0 := y - yb
+	dz0 := z - zb
+
+	// We'll be defining these inside the next block and using them afterwards.
+	var dxExt0, dyExt0, dzExt0 float64
+	var dxExt1, dyExt1, dzExt1 float64
+	var xsvExt0, ysvExt0, zsvExt0 int32
+	var xsvExt1, ysvExt1, zsvExt1 int32
+
+	var k kernel3D
+	if inSum <= 1 { // We're inside the tetrahedron (3-Simplex) at (0,0,0)
+
+		// Determine which two of (0,0,1), (0,1,0), (1,0,0) are closest.
+		aPoint := byte(0x01)
+		bPoint := byte(0x02)
+		aScore := xins
+		bScore := yins
+		if aScore >= bScore && zins > bScore {
+			bScore = zins
+			bPoint = 0x04
+		} else if aScore < bScore && zins > aScore {
+			aScore = zins
+			aPoint = 0x04
+		}
+
+		// Now we determine the two lattice points not part of the tetrahedron that may contribute.
+		// This depends on the closest two tetrahedral vertices, including (0,0,0)
+		wins := 1 - inSum
+		if wins > aScore || wins > bScore { // (0,0,0) is one of the closest two tetrahedral vertices.
+			var c byte // Our other closest vertex is the closest out of a and b.
+			if bScore > aScore {
+				c = bPoint
+			} else {
+				c = aPoint
+			}
+
+			if (c & 0x01) == 0 {
+				xsvExt0 = xsb - 1
+				xsvExt1 = xsb
+				dxExt0 = dx0 + 1
+				dxExt1 = dx0
+			} else {
+				xsvExt1 = xsb + 1
+				xsvExt0 = xsvExt1
+				dxExt1 = dx0 - 1
+				dxExt0 = dxExt1
+			}
+
+			if (c & 0x02) == 0 {
+				ysvExt1 = ysb
+				ysvExt0 = ysvExt1
+				dyExt1 = dy0
+				dyExt0 = dyExt1
+				if (c & 0x01) == 0 {
+					ysvExt1 -= 1
+					dyExt1 += 1
+				} else {
+					ysvExt0 -= 1
+					dyExt0 += 1
+				}
+			} else {
+				ysvExt1 = ysb + 1
+				ysvExt0 = ysvExt1
+				dyExt1 = dy0 - 1
+				dyExt0 = dyExt1
+			}
+
+			if (c & 0x04) == 0 {
+				zsvExt0 = zsb
+				zsvExt1 = zsb - 1
+				dzExt0 = dz0
+				dzExt1 = dz0 + 1
+			} else {
+				zsvExt1 = zsb + 1
+				zsvExt0 = zsvExt1
+				dzExt1 = dz0 - 1
+				dzExt0 = dzExt1
+			}
+		} else { // (0,0,0) is not one of the closest two tetrahedral vertices.
+			c := aPoint | bPoint // Our two extra vertices are determined by the closest two.
+
+			if (c & 0x01) == 0 {
+				xsvExt0 = xsb
+				xsvExt1 = xsb - 1
+				dxExt0 = dx0 - 2*squishConstant3D
+				dxExt1 = dx0 + 1 - squishConstant3D
+			} else {
+				xsvExt1 = xsb + 1
+				xsvExt0 = xsvExt1
+				dxExt0 = dx0 - 1 - 2*squishConstant3D
+				dxExt1 = dx0 - 1 - squishConstant3D
+			}
+
+			if (c & 0x02) == 0 {
+				ysvExt0 = ysb
+				ysvExt1 = ysb - 1
+				dyExt0 = dy0 - 2*squishConstant3D
+				dyExt1 = dy0 + 1 - squishConstant3D
+			} else {
+				ysvExt1 = ysb + 1
+				ysvExt0 = ysvExt1
+				dyExt0 = dy0 - 1 - 2*squishConstant3D
+				dyExt1 = dy0 - 1 - squishConstant3D
+			}
+
+			if (c & 0x04) == 0 {
+				zsvExt0 = zsb
+				zsvExt1 = zsb - 1
+				dzExt0 = dz0 - 2*squishConstant3D
+				dzExt1 = dz0 + 1 - squishConstant3D
+			} else {
+				zsvExt1 = zsb + 1
+				zsvExt0 = zsvExt1
+				dzExt0 = dz0 - 1 - 2*squishConstant3D
+				dzExt1 = dz0 - 1 - squishConstant3D
+			}
+		}
+
+		// Contribution (0,0,0)
+		k.add(p, xsb+0, ysb+0, zsb+0, dx0, dy0, dz0)
+
+		// Contribution (1,0,0)
+		dx1 := dx0 - 1 - squishConstant3D
+		dy1 := dy0 - 0 - squishConstant3D
+		dz1 := dz0 - 0 - squishConstant3D
+		k.add(p, xsb+1, ysb+0, zsb+0, dx1, dy1, dz1)
+
+		// Contribution (0,1,0)
+		dx2 := dx0 - 0 - squishConstant3D
+		dy2 := dy0 - 1 - squishConstant3D
+		dz2 := dz1
+		k.add(p, xsb+0, ysb+1, zsb+0, dx2, dy2, dz2)
+
+		// Contribution (0,0,1)
+		dx3 := dx2
+		dy3 := dy1
+		dz3 := dz0 - 1 - squishConstant3D
+		k.add(p, xsb+0, ysb+0, zsb+1, dx3, dy3, dz3)
+	} else if inSum >= 2 { // We're inside the tetrahedron (3-Simplex) at (1,1,1)
+
+		// Determine which two tetrahedral vertices are the closest, out of (1,1,0), (1,0,1), (0,1,1) but not (1,1,1).
+		aPoint := byte(0x06)
+		aScore := xins
+		bPoint := byte(0x05)
+		bScore := yins
+		if aScore <= bScore && zins < bScore {
+			bScore = zins
+			bPoint = 0x03
+		} else if aScore > bScore && zins < aScore {
+			aScore = zins
+			aPoint = 0x03
+		}
+
+		// Now we determine the two lattice points not part of the tetrahedron that may contribute.
+		// This depends on the closest two tetrahedral vertices, including (1,1,1)
+		wins := 3 - inSum
+		if wins < aScore || wins < bScore { // (1,1,1) is one of the closest two tetrahedral vertices.
+			var c byte // Our other closest vertex is the closest out of a and b.
+			if bScore < aScore {
+				c = bPoint
+			} else {
+				c = aPoint
+			}
+
+			if (c & 0x01) != 0 {
+				xsvExt0 = xsb + 2
+				xsvExt1 = xsb + 1
+				dxExt0 = dx0 - 2 - 3*squishConstant3D
+				dxExt1 = dx0 - 1 - 3*squishConstant3D
+			} else {
+				xsvExt1 = xsb
+				xsvExt0 = xsvExt1
+				dxExt1 = dx0 - 3*squishConstant3D
+				dxExt0 = dxExt1
+			}
+
+			if (c & 0x02) != 0 {
+				ysvExt1 = ysb + 1
+				ysvExt0 = ysvExt1
+				dyExt1 = dy0 - 1 - 3*squishConstant3D
+				dyExt0 = dyExt1
+				if (c & 0x01) != 0 {
+					ysvExt1 += 1
+					dyExt1 -= 1
+				} else {
+					ysvExt0 += 1
+					dyExt0 -= 1
+				}
+			} else {
+				ysvExt1 = ysb
+				ysvExt0 = ysvExt1
+				dyExt1 = dy0 - 3*squishConstant3D
+				dyExt0 = dyExt1
+			}
+
+			if (c & 0x04) != 0 {
+				zsvExt0 = zsb + 1
+				zsvExt1 = zsb + 2
+				dzExt0 = dz0 - 1 - 3*squishConstant3D
+				dzExt1 = dz0 - 2 - 3*squishConstant3D
+			} else {
+				zsvExt1 = zsb
+				zsvExt0 = zsvExt1
+				dzExt1 = dz0 - 3*squishConstant3D
+				dzExt0 = dzExt1
+			}
+		} else { // (1,1,1) is not one of the closest two tetrahedral vertices.
+			c := aPoint & bPoint // Our two extra vertices are determined by the closest two.
+
+			if (c & 0x01) != 0 {
+				xsvExt0 = xsb + 1
+				xsvExt1 = xsb + 2
+				dxExt0 = dx0 - 1 - squishConstant3D
+				dxExt1 = dx0 - 2 - 2*squishConstant3D
+			} else {
+				xsvExt1 = xsb
+				xsvExt0 = xsvExt1
+				dxExt0 = dx0 - squishConstant3D
+				dxExt1 = dx0 - 2*squishConstant3D
+			}
+
+			if (c & 0x02) != 0 {
+				ysvExt0 = ysb + 1
+				ysvExt1 = ysb + 2
+				dyExt0 = dy0 - 1 - squishConstant3D
+				dyExt1 = dy0 - 2 - 2*squishConstant3D
+			} else {
+				ysvExt1 = ysb
+				ysvExt0 = ysvExt1
+				dyExt0 = dy0 - squishConstant3D
+				dyExt1 = dy0 - 2*squishConstant3D
+			}
+
+			if (c & 0x04) != 0 {
+				zsvExt0 = zsb + 1
+				zsvExt1 = zsb + 2
+				dzExt0 = dz0 - 1 - squishConstant3D
+				dzExt1 = dz0 - 2 - 2*squishConstant3D
+			} else {
+				zsvExt1 = zsb
+				zsvExt0 = zsvExt1
+				dzExt0 = dz0 - squishConstant3D
+				dzExt1 = dz0 - 2*squishConstant3D
+			}
+		}
+
+		// Contribution (1,1,0)
+		dx3 := dx0 - 1 - 2*squishConstant3D
+		dy3 := dy0 - 1 - 2*squishConstant3D
+		dz3 := dz0 - 0 - 2*squishConstant3D
+		k.add(p, xsb+1, ysb+1, zsb+0, dx3, dy3, dz3)
+
+		// Contribution (1,0,1)
+		dx2 := dx3
+		dy2 := dy0 - 0 - 2*squishConstant3D
+		dz2 := dz0 - 1 - 2*squishConstant3D
+		k.add(p, xsb+1, ysb+0, zsb+1, dx2, dy2, dz2)
+
+		// Contribution (0,1,1)
+		dx1 := dx0 - 0 - 2*squishConstant3D
+		dy1 := dy3
+		dz1 := dz2
+		k.add(p, xsb+0, ysb+1, zsb+1, dx1, dy1, dz1)
+
+		// Contribution (1,1,1)
+		dx0 = dx0 - 1 - 3*squishConstant3D
+		dy0 = dy0 - 1 - 3*squishConstant3D
+		dz0 = dz0 - 1 - 3*squishConstant3D
+		k.add(p, xsb+1, ysb+1, zsb+1, dx0, dy0, dz0)
+	} else { // We're inside the octahedron (Rectified 3-Simplex) in between.
+		var aScore, bScore float64
+		var aPoint, bPoint byte
+		var aIsFurtherSide, bIsFurtherSide bool
+
+		// Decide between point (0,0,1) and (1,1,0) as closest
+		p1 := xins + yins
+		if p1 > 1 {
+			aScore = p1 - 1
+			aPoint = 0x03
+			aIsFurtherSide = true
+		} else {
+			aScore = 1 - p1
+			aPoint = 0x04
+			aIsFurtherSide = false
+		}
+
+		// Decide between point (0,1,0) and (1,0,1) as closest
+		p2 := xins + zins
+		if p2 > 1 {
+			bScore = p2 - 1
+			bPoint = 0x05
+			bIsFurtherSide = true
+		} else {
+			bScore = 1 - p2
+			bPoint = 0x02
+			bIsFurtherSide = false
+		}
+
+		// The closest out of the two (1,0,0) and (0,1,1) will replace the furthest out of the two decided above, if closer.
+		p3 := yins + zins
+		if p3 > 1 {
+			score := p3 - 1
+			if aScore <= bScore && aScore < score {
+				aScore = score
+				aPoint = 0x06
+				aIsFurtherSide = true
+			} else if aScore > bScore && bScore < score {
+				bScore = score
+				bPoint = 0x06
+				bIsFurtherSide = true
+			}
+		} else {
+			score := 1 - p3
+			if aScore <= bScore && aScore < score {
+				aScore = score
+				aPoint = 0x01
+				aIsFurtherSide = false
+			} else if aScore > bScore && bScore < score {
+				bScore = score
+				bPoint = 0x01
+				bIsFurtherSide = false
+			}
+		}
+
+		// Where each of the two closest points are determines how the extra two vertices are calculated.
+		if aIsFurtherSide == bIsFurtherSide {
+			if aIsFurtherSide { // Both closest points on (1,1,1) side
+
+				// One of the two extra points is (1,1,1)
+				dxExt0 = dx0 - 1 - 3*squishConstant3D
+				dyExt0 = dy0 - 1 - 3*squishConstant3D
+				dzExt0 = dz0 - 1 - 3*squishConstant3D
+				xsvExt0 = xsb + 1
+				ysvExt0 = ysb + 1
+				zsvExt0 = zsb + 1
+
+				// Other extra point is based on the shared axis.
+				c := aPoint & bPoint
+				if (c & 0x01) != 0 {
+					dxExt1 = dx0 - 2 - 2*squishConstant3D
+					dyExt1 = dy0 - 2*squishConstant3D
+					dzExt1 = dz0 - 2*squishConstant3D
+					xsvExt1 = xsb + 2
+					ysvExt1 = ysb
+					zsvExt1 = zsb
+				} else if (c & 0x02) != 0 {
+					dxExt1 = dx0 - 2*squishConstant3D
+					dyExt1 = dy0 - 2 - 2*squishConstant3D
+					dzExt1 = dz0 - 2*squishConstant3D
+					xsvExt1 = xsb
+					ysvExt1 = ysb + 2
+					zsvExt1 = zsb
+				} else {
+					dxExt1 = dx0 - 2*squishConstant3D
+					dyExt1 = dy0 - 2*squishConstant3D
+					dzExt1 = dz0 - 2 - 2*squishConstant3D
+					xsvExt1 = xsb
+					ysvExt1 = ysb
+					zsvExt1 = zsb + 2
+				}
+			} else { // Both closest points on (0,0,0) side
+
+				// One of the two extra points is (0,0,0)
+				dxExt0 = dx0
+				dyExt0 = dy0
+				dzExt0 = dz0
+				xsvExt0 = xsb
+				ysvExt0 = ysb
+				zsvExt0 = zsb
+
+				// Other extra point is based on the omitted axis.
+				c := aPoint | bPoint
+				if (c & 0x01) == 0 {
+					dxExt1 = dx0 + 1 - squishConstant3D
+					dyExt1 = dy0 - 1 - squishConstant3D
+					dzExt1 = dz0 - 1 - squishConstant3D
+					xsvExt1 = xsb - 1
+					ysvExt1 = ysb + 1
+					zsvExt1 = zsb + 1
+				} else if (c & 0x02) == 0 {
+					dxExt1 = dx0 - 1 - squishConstant3D
+					dyExt1 = dy0 + 1 - squishConstant3D
+					dzExt1 = dz0 - 1 - squishConstant3D
+					xsvExt1 = xsb + 1
+					ysvExt1 = ysb - 1
+					zsvExt1 = zsb + 1
+				} else {
+					dxExt1 = dx0 - 1 - squishConstant3D
+					dyExt1 = dy0 - 1 - squishConstant3D
+					dzExt1 = dz0 + 1 - squishConstant3D
+					xsvExt1 = xsb + 1
+					ysvExt1 = ysb + 1
+					zsvExt1 = zsb - 1
+				}
+			}
+		} else { // One point on (0,0,0) side, one point on (1,1,1) side
+			var c1, c2 byte
+			if aIsFurtherSide {
+				c1 = aPoint
+				c2 = bPoint
+			} else {
+				c1 = bPoint
+				c2 = aPoint
+			}
+
+			// One contribution is a permutation of (1,1,-1)
+			if (c1 & 0x01) == 0 {
+				dxExt0 = dx0 + 1 - squishConstant3D
+				dyExt0 = dy0 - 1 - squishConstant3D
+				dzExt0 = dz0 - 1 - squishConstant3D
+				xsvExt0 = xsb - 1
+				ysvExt0 = ysb + 1
+				zsvExt0 = zsb + 1
+			} else if (c1 & 0x02) == 0 {
+				dxExt0 = dx0 - 1 - squishConstant3D
+				dyExt0 = dy0 + 1 - squishConstant3D
+				dzExt0 = dz0 - 1 - squishConstant3D
+				xsvExt0 = xsb + 1
+				ysvExt0 = ysb - 1
+				zsvExt0 = zsb + 1
+			} else {
+				dxExt0 = dx0 - 1 - squishConstant3D
+				dyExt0 = dy0 - 1 - squishConstant3D
+				dzExt0 = dz0 + 1 - squishConstant3D
+				xsvExt0 = xsb + 1
+				ysvExt0 = ysb + 1
+				zsvExt0 = zsb - 1
+			}
+
+			// One contribution is a permutation of (0,0,2)
+			dxExt1 = dx0 - 2*squishConstant3D
+			dyExt1 = dy0 - 2*squishConstant3D
+			dzExt1 = dz0 - 2*squishConstant3D
+			xsvExt1 = xsb
+			ysvExt1 = ysb
+			zsvExt1 = zsb
+			if (c2 & 0x01) != 0 {
+				dxExt1 -= 2
+				xsvExt1 += 2
+			} else if (c2 & 0x02) != 0 {
+				dyExt1 -= 2
+				ysvExt1 += 2
+			} else {
+				dzExt1 -= 2
+				zsvExt1 += 2
+			}
+		}
+
+		// Contribution (1,0,0)
+		dx1 := dx0 - 1 - squishConstant3D
+		dy1 := dy0 - 0 - squishConstant3D
+		dz1 := dz0 - 0 - squishConstant3D
+		k.add(p, xsb+1, ysb+0, zsb+0, dx1, dy1, dz1)
+
+		// Contribution (0,1,0)
+		dx2 := dx0 - 0 - squishConstant3D
+		dy2 := dy0 - 1 - squishConstant3D
+		dz2 := dz1
+		k.add(p, xsb+0, ysb+1, zsb+0, dx2, dy2, dz2)
+
+		// Contribution (0,0,1)
+		dx3 := dx2
+		dy3 := dy1
+		dz3 := dz0 - 1 - squishConstant3D
+		k.add(p, xsb+0, ysb+0, zsb+1, dx3, dy3, dz3)
+
+		// Contribution (1,1,0)
+		dx4 := dx0 - 1 - 2*squishConstant3D
+		dy4 := dy0 - 1 - 2*squishConstant3D
+		dz4 := dz0 - 0 - 2*squishConstant3D
+		k.add(p, xsb+1, ysb+1, zsb+0, dx4, dy4, dz4)
+
+		// Contribution (1,0,1)
+		dx5 := dx4
+		dy5 := dy0 - 0 - 2*squishConstant3D
+		dz5 := dz0 - 1 - 2*squishConstant3D
+		k.add(p, xsb+1, ysb+0, zsb+1, dx5, dy5, dz5)
+
+		// Contribution (0,1,1)
+		dx6 := dx0 - 0 - 2*squishConstant3D
+		dy6 := dy4
+		dz6 := dz5
+		k.add(p, xsb+0, ysb+1, zsb+1, dx6, dy6, dz6)
+	}
+
+	// First extra vertex
+	k.add(p, xsvExt0, ysvExt0, zsvExt0, dxExt0, dyExt0, dzExt0)
+
+	// Second extra vertex
+	k.add(p, xsvExt1, ysvExt1, zsvExt1, dxExt1, dyExt1, dzExt1)
+
+	return k.result()
+}
+
+// ----------------------------------------------------------------------------
+//  Type: kernel2D
+// ----------------------------------------------------------------------------
+
+// kernel2D accumulates the kernels of the 2D lattice vertices and their
+// derivatives.
+type kernel2D struct {
+	value, dx, dy float64
+}
+
+// add adds the kernel of the vertex (xsv, ysv) at the offset (dx0, dy0) from it.
+func (k *kernel2D) add(p *permutation, xsv, ysv int32, dx0, dy0 float64) {
+	attn := 2 - dx0*dx0 - dy0*dy0
+	if attn <= 0 {
+		return
+	}
+
+	index := p.perm[(int32(p.perm[xsv&0xFF])+ysv)&0xFF] & 0x0E
+	gx, gy := gradients2D[index], gradients2D[index+1]
+	ext := gx*dx0 + gy*dy0
+
+	// d/dx attn^4*ext = attn^4*gx - 8*attn^3*dx0*ext
+	attn2 := attn * attn
+	attn3 := attn2 * attn
+
+	k.value += attn2 * attn2 * ext
+	k.dx += attn3 * (attn*gx - 8*dx0*ext)
+	k.dy += attn3 * (attn*gy - 8*dy0*ext)
+}
+
+// result returns the normalized value and derivatives.
+func (k *kernel2D) result() (float64, float64, float64) {
+	return k.value / normConstant2D, k.dx / normConstant2D, k.dy / normConstant2D
+}
+
+// ----------------------------------------------------------------------------
+//  Type: kernel3D
+// ----------------------------------------------------------------------------
+
+// kernel3D accumulates the kernels of the 3D lattice vertices and their
+// derivatives.
+type kernel3D struct {
+	value, dx, dy, dz float64
+}
+
+// add adds the kernel of the vertex (xsv, ysv, zsv) at the offset (dx0, dy0,
+// dz0) from it.
+func (k *kernel3D) add(p *permutation, xsv, ysv, zsv int32, dx0, dy0, dz0 float64) {
+	attn := 2 - dx0*dx0 - dy0*dy0 - dz0*dz0
+	if attn <= 0 {
+		return
+	}
+
+	index := p.permGradIndex3D[(int32(p.perm[(int32(p.perm[xsv&0xFF])+ysv)&0xFF])+zsv)&0xFF]
+	gx, gy, gz := gradients3D[index], gradients3D[index+1], gradients3D[index+2]
+	ext := gx*dx0 + gy*dy0 + gz*dz0
+
+	attn2 := attn * attn
+	attn3 := attn2 * attn
+
+	k.value += attn2 * attn2 * ext
+	k.dx += attn3 * (attn*gx - 8*dx0*ext)
+	k.dy += attn3 * (attn*gy - 8*dy0*ext)
+	k.dz += attn3 * (attn*gz - 8*dz0*ext)
+}
+
+// result returns the normalized value and derivatives.
+func (k *kernel3D) result() (float64, float64, float64, float64) {
+	return k.value / normConstant3D, k.dx / normConstant3D, k.dy / normConstant3D, k.dz / normConstant3D
+}
@@ -83,14 +83,35 @@ func ExampleNew_eval32_three_dimmentions() {
 	// -0.0171
 }
 
-func ExampleNew_eval32_more_than_three_dimmentions() {
+func ExampleNew_eval32_four_dimmentions() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	p := opensimplex.New(seed)
+
+	for w := float32(0); w < 3; w++ {
+		fmt.Printf(
+			"%0.0f; %0.4f\n",
+			w,
+			p.Eval32(0.1, 0.2, 0.3, w/smoothness),
+		)
+	}
+
+	// Output:
+	// 0; 0.3014
+	// 1; 0.4402
+	// 2; 0.5556
+}
+
+func ExampleNew_eval32_more_than_four_dimmentions() {
 	const seed = 100
 
 	p := opensimplex.New(seed)
 
-	// It only supports up to three dimmentions.
-	// OpenSimplex itself supports more but currently we strictly limit it to three.
-	fmt.Printf("%0.0f", p.Eval32(0.0001, 0.0001, 0.0001, 0.0001))
+	// It only supports up to four dimmentions.
+	fmt.Printf("%0.0f", p.Eval32(0.1, 0.2, 0.3, 0.4, 0.5))
 
 	// Output: 0
 }
@@ -180,14 +201,35 @@ func ExampleNew_eval64_three_dimmentions() {
 	// -0.0171
 }
 
-func ExampleNew_eval64_more_than_three_dimmentions() {
+func ExampleNew_eval64_four_dimmentions() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	p := opensimplex.New(seed)
+
+	for w := float64(0); w < 3; w++ {
+		fmt.Printf(
+			"%0.0f; %0.4f\n",
+			w,
+			p.Eval64(0.1, 0.2, 0.3, w/smoothness),
+		)
+	}
+
+	// Output:
+	// 0; 0.3014
+	// 1; 0.4402
+	// 2; 0.5556
+}
+
+func ExampleNew_eval64_more_than_four_dimmentions() {
 	const seed = 100
 
 	p := opensimplex.New(seed)
 
-	// It only supports up to three dimmentions.
-	// OpenSimplex itself supports more but currently we strictly limit it to three.
-	fmt.Printf("%0.0f", p.Eval64(0.0001, 0.0001, 0.0001, 0.0001))
+	// It only supports up to four dimmentions.
+	fmt.Printf("%0.0f", p.Eval64(0.1, 0.2, 0.3, 0.4, 0.5))
 
 	// Output: 0
 }
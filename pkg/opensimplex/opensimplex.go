@@ -1,11 +1,17 @@
 package opensimplex
 
 import (
+	"sync/atomic"
+
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/pkg/errors"
 
 	orig "github.com/ojrac/opensimplex-go"
 )
 
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
 // ----------------------------------------------------------------------------
 //  Constructor
 // ----------------------------------------------------------------------------
@@ -23,9 +29,25 @@ func New(seed int64) *Generator {
 
 // Generator holds parameter values for OpenSimplex noise. It is an implementation
 // of Generator interface.
+//
+// It is safe to call the evaluation methods concurrently from multiple goroutines.
+// But the Seed must not be changed while evaluating.
 type Generator struct {
 	// Seed holds the seed value for the noise.
 	Seed int64
+	// cache holds the *state, the permutation state built from the current seed.
+	// It is built on the first evaluation and rebuilt only when the Seed was
+	// changed.
+	cache atomic.Value
+}
+
+// state is a built opensimplex-go instance, the replica of its permutation
+// tables and the seed used to build them.
+type state struct {
+	noise64 orig.Noise
+	noise32 orig.Noise32
+	perm    *permutation
+	seed    int64
 }
 
 // ----------------------------------------------------------------------------
@@ -33,6 +55,8 @@ type Generator struct {
 // ----------------------------------------------------------------------------
 
 // Eval32 returns a float32 noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
 func (n *Generator) Eval32(dim ...float32) float32 {
 	switch len(dim) {
 	case 1:
@@ -41,12 +65,16 @@ func (n *Generator) Eval32(dim ...float32) float32 {
 		return n.eval2D32(dim[0], dim[1])
 	case 3:
 		return n.eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.eval4D32(dim[0], dim[1], dim[2], dim[3])
 	}
 
 	return 0
 }
 
 // Eval64 returns a float64 noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
 func (n *Generator) Eval64(dim ...float64) float64 {
 	switch len(dim) {
 	case 1:
@@ -55,11 +83,81 @@ func (n *Generator) Eval64(dim ...float64) float64 {
 		return n.eval2D64(dim[0], dim[1])
 	case 3:
 		return n.eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.eval4D64(dim[0], dim[1], dim[2], dim[3])
 	}
 
 	return 0
 }
 
+// Eval1D32 returns a float32 OpenSimplex noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return n.eval1D32(x)
+}
+
+// Eval2D32 returns a float32 OpenSimplex noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return n.eval2D32(x, y)
+}
+
+// Eval3D32 returns a float32 OpenSimplex noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return n.eval3D32(x, y, z)
+}
+
+// Eval4D32 returns a float32 OpenSimplex noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return n.eval4D32(x, y, z, w)
+}
+
+// Eval1D64 returns a float64 OpenSimplex noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return n.eval1D64(x)
+}
+
+// Eval2D64 returns a float64 OpenSimplex noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return n.eval2D64(x, y)
+}
+
+// Eval3D64 returns a float64 OpenSimplex noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return n.eval3D64(x, y, z)
+}
+
+// Eval4D64 returns a float64 OpenSimplex noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return n.eval4D64(x, y, z, w)
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
 // SetEval32 is an implementation of noise.Generator interface. It will always
 // return an error.
 func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
@@ -72,48 +170,204 @@ func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error
 	return errors.New("float64 evaluation function is already set. You can not set custom function in OpenSimplex type")
 }
 
+// EvalGrid2D32 fills dst with the float32 OpenSimplex noise values at the sample
+// points of the 2D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval32(x, y) at the same sample point.
+func (n *Generator) EvalGrid2D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise32()
+
+	for y := 0; y < g.Height; y++ {
+		yy := float32(g.AtY(y))
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = p.Eval2(float32(g.AtX(x)), yy)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid2D64 fills dst with the float64 OpenSimplex noise values at the sample
+// points of the 2D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval64(x, y) at the same sample point.
+func (n *Generator) EvalGrid2D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise64()
+
+	for y := 0; y < g.Height; y++ {
+		yy := g.AtY(y)
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = p.Eval2(g.AtX(x), yy)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D32 fills dst with the float32 OpenSimplex noise values at the sample
+// points of the 3D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval32(x, y, z) at the same sample point.
+func (n *Generator) EvalGrid3D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise32()
+
+	for z := 0; z < g.Depth; z++ {
+		zz := float32(g.AtZ(z))
+
+		for y := 0; y < g.Height; y++ {
+			yy := float32(g.AtY(y))
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = p.Eval3(float32(g.AtX(x)), yy, zz)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D64 fills dst with the float64 OpenSimplex noise values at the sample
+// points of the 3D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval64(x, y, z) at the same sample point.
+func (n *Generator) EvalGrid3D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise64()
+
+	for z := 0; z < g.Depth; z++ {
+		zz := g.AtZ(z)
+
+		for y := 0; y < g.Height; y++ {
+			yy := g.AtY(y)
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = p.Eval3(g.AtX(x), yy, zz)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 //  Methods (Private)
 // ----------------------------------------------------------------------------
 
 // eval1D32 generates float32 OpenSimplex noise value from 1-dimensional coordinate.
 func (n *Generator) eval1D32(x float32) float32 {
-	p := orig.New32(n.Seed)
+	p := n.noise32()
 
 	return p.Eval2(x, x)
 }
 
 // eval2D32 generates float32 OpenSimplex noise value from 2-dimensional coordinates.
 func (n *Generator) eval2D32(x, y float32) float32 {
-	p := orig.New32(n.Seed)
+	p := n.noise32()
 
 	return p.Eval2(x, y)
 }
 
 // eval3D32 generates float32 OpenSimplex noise value from 3-dimensional coordinates.
 func (n *Generator) eval3D32(x, y, z float32) float32 {
-	p := orig.New32(n.Seed)
+	p := n.noise32()
 
 	return p.Eval3(x, y, z)
 }
 
+// eval4D32 generates float32 OpenSimplex noise value from 4-dimensional coordinates.
+func (n *Generator) eval4D32(x, y, z, w float32) float32 {
+	p := n.noise32()
+
+	return p.Eval4(x, y, z, w)
+}
+
 // eval1D64 generates float64 OpenSimplex noise value from 1-dimensional coordinate.
 func (n *Generator) eval1D64(x float64) float64 {
-	p := orig.New(n.Seed)
+	p := n.noise64()
 
 	return p.Eval2(x, x)
 }
 
 // eval2D64 generates float64 OpenSimplex noise value from 2-dimensional coordinates.
 func (n *Generator) eval2D64(x, y float64) float64 {
-	p := orig.New(n.Seed)
+	p := n.noise64()
 
 	return p.Eval2(x, y)
 }
 
 // eval3D64 generates float64 OpenSimplex noise value from 3-dimensional coordinates.
 func (n *Generator) eval3D64(x, y, z float64) float64 {
-	p := orig.New(n.Seed)
+	p := n.noise64()
 
 	return p.Eval3(x, y, z)
 }
+
+// eval4D64 generates float64 OpenSimplex noise value from 4-dimensional coordinates.
+func (n *Generator) eval4D64(x, y, z, w float64) float64 {
+	p := n.noise64()
+
+	return p.Eval4(x, y, z, w)
+}
+
+// noise32 returns the cached float32 OpenSimplex instance.
+func (n *Generator) noise32() orig.Noise32 {
+	return n.state().noise32
+}
+
+// noise64 returns the cached float64 OpenSimplex instance.
+func (n *Generator) noise64() orig.Noise {
+	return n.state().noise64
+}
+
+// state returns the cached OpenSimplex instances. It rebuilds them only if they
+// have not been built yet or the Seed was changed.
+func (n *Generator) state() *state {
+	if c, ok := n.cache.Load().(*state); ok && c.seed == n.Seed {
+		return c
+	}
+
+	c := &state{
+		noise64: orig.New(n.Seed),
+		noise32: orig.New32(n.Seed),
+		perm:    newPermutation(n.Seed),
+		seed:    n.Seed,
+	}
+
+	n.cache.Store(c)
+
+	return c
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. OpenSimplex supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
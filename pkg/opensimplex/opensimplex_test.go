@@ -6,7 +6,9 @@ import (
 	"os"
 	"testing"
 
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/KEINOS/go-noise/pkg/opensimplex"
+	orig "github.com/ojrac/opensimplex-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -29,7 +31,8 @@ func TestSamplesMatch(t *testing.T) {
 			expected = s[3]
 			actual = n.Eval64(s[0], s[1], s[2])
 		case 5:
-			continue
+			expected = s[4]
+			actual = n.Eval64(s[0], s[1], s[2], s[3])
 		default:
 			t.Fatalf("Unexpected size sample: %d", len(s))
 		}
@@ -41,6 +44,344 @@ func TestSamplesMatch(t *testing.T) {
 	}
 }
 
+func TestSamplesMatch_float32(t *testing.T) {
+	samples = loadSamples(t)
+
+	n := opensimplex.New(0)
+	o := orig.New32(0)
+
+	for _, s := range samples {
+		if len(s) != 5 {
+			continue
+		}
+
+		x, y, z, w := float32(s[0]), float32(s[1]), float32(s[2]), float32(s[3])
+
+		require.Equal(t, o.Eval4(x, y, z, w), n.Eval32(x, y, z, w),
+			"4D float32 value should match the original at %v", s[:4])
+	}
+}
+
+func TestGenerator_rebuild_on_seed_change(t *testing.T) {
+	const (
+		x = 0.1
+		y = 0.2
+	)
+
+	n := opensimplex.New(100)
+
+	before64 := n.Eval64(x, y)
+	before32 := n.Eval32(x, y)
+
+	n.Seed = 101
+
+	require.Equal(t, orig.New(101).Eval2(x, y), n.Eval64(x, y),
+		"changing the Seed should rebuild the float64 permutation state")
+	require.Equal(t, orig.New32(101).Eval2(x, y), n.Eval32(x, y),
+		"changing the Seed should rebuild the float32 permutation state")
+	require.NotEqual(t, before64, n.Eval64(x, y), "changing the Seed should change the noise value")
+	require.NotEqual(t, before32, n.Eval32(x, y), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_EvalGrid_equals_to_eval(t *testing.T) {
+	n := opensimplex.New(100)
+	g := &grid.Grid{
+		X: -0.5, Y: 0.25, Z: 1,
+		StepX: 0.1, StepY: 0.07, StepZ: 0.2,
+		Width: 5, Height: 4, Depth: 3,
+	}
+
+	out2D32 := make([]float32, g.Len2D())
+	out2D64 := make([]float64, g.Len2D())
+
+	require.NoError(t, n.EvalGrid2D32(out2D32, g))
+	require.NoError(t, n.EvalGrid2D64(out2D64, g))
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			i := y*g.Width + x
+
+			require.Equal(t, n.Eval32(float32(g.AtX(x)), float32(g.AtY(y))), out2D32[i])
+			require.Equal(t, n.Eval64(g.AtX(x), g.AtY(y)), out2D64[i])
+		}
+	}
+
+	out3D32 := make([]float32, g.Len3D())
+	out3D64 := make([]float64, g.Len3D())
+
+	require.NoError(t, n.EvalGrid3D32(out3D32, g))
+	require.NoError(t, n.EvalGrid3D64(out3D64, g))
+
+	for z := 0; z < g.Depth; z++ {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				i := (z*g.Height+y)*g.Width + x
+
+				require.Equal(t, n.Eval32(float32(g.AtX(x)), float32(g.AtY(y)), float32(g.AtZ(z))), out3D32[i])
+				require.Equal(t, n.Eval64(g.AtX(x), g.AtY(y), g.AtZ(z)), out3D64[i])
+			}
+		}
+	}
+}
+
+func TestGenerator_EvalGrid_short_destination(t *testing.T) {
+	n := opensimplex.New(100)
+	g := &grid.Grid{Width: 5, Height: 4, Depth: 3}
+
+	require.Error(t, n.EvalGrid2D32(make([]float32, 1), g))
+	require.Error(t, n.EvalGrid2D64(make([]float64, 1), g))
+	require.Error(t, n.EvalGrid3D32(make([]float32, g.Len2D()), g))
+	require.Error(t, n.EvalGrid3D64(make([]float64, g.Len2D()), g))
+}
+
+func TestGenerator_EvalDeriv_equals_to_numeric(t *testing.T) {
+	const h = 1e-6
+
+	for _, seed := range []int64{0, 100, -5} {
+		p := opensimplex.New(seed)
+
+		for i := 0; i < 200; i++ {
+			x, y, z := float64(i)*0.173-13.1, float64(i)*0.0917+0.05, 7.3-float64(i)*0.061
+
+			v, dx, dy := p.EvalDeriv2D64(x, y)
+
+			require.Equal(t, p.Eval64(x, y), v, "the value should be the same as Eval64")
+			require.InDelta(t, (p.Eval64(x+h, y)-p.Eval64(x-h, y))/(2*h), dx, 1e-6, "dx at (%v, %v)", x, y)
+			require.InDelta(t, (p.Eval64(x, y+h)-p.Eval64(x, y-h))/(2*h), dy, 1e-6, "dy at (%v, %v)", x, y)
+
+			v, dx, dy, dz := p.EvalDeriv3D64(x, y, z)
+
+			require.Equal(t, p.Eval64(x, y, z), v, "the value should be the same as Eval64")
+			require.InDelta(t, (p.Eval64(x+h, y, z)-p.Eval64(x-h, y, z))/(2*h), dx, 1e-6, "dx at (%v, %v, %v)", x, y, z)
+			require.InDelta(t, (p.Eval64(x, y+h, z)-p.Eval64(x, y-h, z))/(2*h), dy, 1e-6, "dy at (%v, %v, %v)", x, y, z)
+			require.InDelta(t, (p.Eval64(x, y, z+h)-p.Eval64(x, y, z-h))/(2*h), dz, 1e-6, "dz at (%v, %v, %v)", x, y, z)
+		}
+	}
+}
+
+func TestGenerator_EvalDeriv32(t *testing.T) {
+	p := opensimplex.New(100)
+
+	v64, dx64, dy64 := p.EvalDeriv2D64(0.3, 0.7)
+	v32, dx32, dy32 := p.EvalDeriv2D32(0.3, 0.7)
+
+	require.InDelta(t, v64, float64(v32), 1e-6)
+	require.InDelta(t, dx64, float64(dx32), 1e-6)
+	require.InDelta(t, dy64, float64(dy32), 1e-6)
+
+	v64, dx64, dy64, dz64 := p.EvalDeriv3D64(0.3, 0.7, 0.2)
+	v32, dx32, dy32, dz32 := p.EvalDeriv3D32(0.3, 0.7, 0.2)
+
+	require.InDelta(t, v64, float64(v32), 1e-6)
+	require.InDelta(t, dx64, float64(dx32), 1e-6)
+	require.InDelta(t, dy64, float64(dy32), 1e-6)
+	require.InDelta(t, dz64, float64(dz32), 1e-6)
+
+	// The value comes from the float64 evaluation, so it is close to but not
+	// always the same as the float32 one.
+	for i := float32(0); i < 100; i++ {
+		x, y, z := i*0.173-3.1, i*0.0917+0.05, i*0.061+0.02
+
+		v32, _, _ = p.EvalDeriv2D32(x, y)
+		require.InDelta(t, p.Eval2D32(x, y), v32, 1e-6)
+
+		v32, _, _, _ = p.EvalDeriv3D32(x, y, z)
+		require.InDelta(t, p.Eval3D32(x, y, z), v32, 1e-6)
+	}
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	n := opensimplex.New(100)
+
+	for _, dim := range [][]float64{
+		{0.1},
+		{0.1, 0.2},
+		{0.1, 0.2, 0.3},
+		{0.1, 0.2, 0.3, 0.4},
+	} {
+		dim32 := make([]float32, len(dim))
+		for i, v := range dim {
+			dim32[i] = float32(v)
+		}
+
+		v64, err := n.Eval64E(dim...)
+
+		require.NoError(t, err, "%dD should be supported", len(dim))
+		require.Equal(t, n.Eval64(dim...), v64)
+
+		v32, err := n.Eval32E(dim32...)
+
+		require.NoError(t, err, "%dD should be supported", len(dim))
+		require.Equal(t, n.Eval32(dim32...), v32)
+	}
+
+	for _, dim := range [][]float64{
+		{},
+		{0.1, 0.2, 0.3, 0.4, 0.5},
+	} {
+		_, err := n.Eval64E(dim...)
+
+		require.Error(t, err, "%dD should not be supported", len(dim))
+		require.Contains(t, err.Error(), "unsupported number of dimensions")
+
+		_, err = n.Eval32E(make([]float32, len(dim))...)
+
+		require.Error(t, err, "%dD should not be supported", len(dim))
+		require.Contains(t, err.Error(), "unsupported number of dimensions")
+	}
+}
+
+func TestGenerator_fixed_arity_equals_to_variadic(t *testing.T) {
+	n := opensimplex.New(100)
+
+	require.Equal(t, n.Eval32(0.1), n.Eval1D32(0.1))
+	require.Equal(t, n.Eval32(0.1, 0.2), n.Eval2D32(0.1, 0.2))
+	require.Equal(t, n.Eval32(0.1, 0.2, 0.3), n.Eval3D32(0.1, 0.2, 0.3))
+	require.Equal(t, n.Eval32(0.1, 0.2, 0.3, 0.4), n.Eval4D32(0.1, 0.2, 0.3, 0.4))
+	require.Equal(t, n.Eval64(0.1), n.Eval1D64(0.1))
+	require.Equal(t, n.Eval64(0.1, 0.2), n.Eval2D64(0.1, 0.2))
+	require.Equal(t, n.Eval64(0.1, 0.2, 0.3), n.Eval3D64(0.1, 0.2, 0.3))
+	require.Equal(t, n.Eval64(0.1, 0.2, 0.3, 0.4), n.Eval4D64(0.1, 0.2, 0.3, 0.4))
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	n := opensimplex.New(100)
+
+	// Build the cached state before measuring.
+	_ = n.Eval1D64(0)
+
+	for name, eval := range map[string]func(){
+		"Eval1D32": func() { _ = n.Eval1D32(0.1) },
+		"Eval2D32": func() { _ = n.Eval2D32(0.1, 0.2) },
+		"Eval3D32": func() { _ = n.Eval3D32(0.1, 0.2, 0.3) },
+		"Eval4D32": func() { _ = n.Eval4D32(0.1, 0.2, 0.3, 0.4) },
+		"Eval1D64": func() { _ = n.Eval1D64(0.1) },
+		"Eval2D64": func() { _ = n.Eval2D64(0.1, 0.2) },
+		"Eval3D64": func() { _ = n.Eval3D64(0.1, 0.2, 0.3) },
+		"Eval4D64": func() { _ = n.Eval4D64(0.1, 0.2, 0.3, 0.4) },
+	} {
+		require.Zero(t, testing.AllocsPerRun(100, eval), "%s should not allocate", name)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Benchmarks
+// ----------------------------------------------------------------------------
+//  The "Rebuild" benchmarks re-create the opensimplex-go instance on every call
+//  to compare with the cached state of the Generator.
+
+func BenchmarkGenerator_Eval32_1D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval32(float32(i) / 100)
+	}
+}
+
+func BenchmarkGenerator_Eval32_2D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval32(float32(i)/100, float32(i)/200)
+	}
+}
+
+func BenchmarkGenerator_Eval32_3D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval32(float32(i)/100, float32(i)/200, float32(i)/300)
+	}
+}
+
+func BenchmarkGenerator_Eval64_1D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval64(float64(i) / 100)
+	}
+}
+
+func BenchmarkGenerator_Eval64_2D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval64(float64(i)/100, float64(i)/200)
+	}
+}
+
+func BenchmarkGenerator_Eval64_3D(b *testing.B) {
+	n := opensimplex.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = n.Eval64(float64(i)/100, float64(i)/200, float64(i)/300)
+	}
+}
+
+func BenchmarkRebuild_Eval32_1D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New32(100).Eval2(float32(i)/100, float32(i)/100)
+	}
+}
+
+func BenchmarkRebuild_Eval32_2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New32(100).Eval2(float32(i)/100, float32(i)/200)
+	}
+}
+
+func BenchmarkRebuild_Eval32_3D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New32(100).Eval3(float32(i)/100, float32(i)/200, float32(i)/300)
+	}
+}
+
+func BenchmarkRebuild_Eval64_1D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New(100).Eval2(float64(i)/100, float64(i)/100)
+	}
+}
+
+func BenchmarkRebuild_Eval64_2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New(100).Eval2(float64(i)/100, float64(i)/200)
+	}
+}
+
+func BenchmarkRebuild_Eval64_3D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = orig.New(100).Eval3(float64(i)/100, float64(i)/200, float64(i)/300)
+	}
+}
+
+func BenchmarkGenerator_EvalGrid2D64(b *testing.B) {
+	n := opensimplex.New(100)
+	g := &grid.Grid{StepX: 0.01, StepY: 0.01, Width: 100, Height: 100}
+	dst := make([]float64, g.Len2D())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = n.EvalGrid2D64(dst, g)
+	}
+}
+
+func BenchmarkGenerator_Eval64_grid2D(b *testing.B) {
+	n := opensimplex.New(100)
+	g := &grid.Grid{StepX: 0.01, StepY: 0.01, Width: 100, Height: 100}
+	dst := make([]float64, g.Len2D())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				dst[y*g.Width+x] = n.Eval64(g.AtX(x), g.AtY(y))
+			}
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 //  Helper Functions
 // ----------------------------------------------------------------------------
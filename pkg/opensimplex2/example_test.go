@@ -0,0 +1,74 @@
+package opensimplex2_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/opensimplex2"
+)
+
+func ExampleNew() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := opensimplex2.New(seed)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f\n",
+			gen.Eval64(x/smoothness),
+			gen.Eval64(x/smoothness, 0.3),
+			gen.Eval64(x/smoothness, 0.3, 0.6),
+			gen.Eval64(x/smoothness, 0.3, 0.6, 0.9),
+		)
+	}
+
+	// Output:
+	// 0.5706;0.0650;0.2440;-0.0018
+	// 0.8799;0.0425;0.4689;-0.0356
+	// 0.7555;-0.1757;0.5691;-0.1095
+}
+
+func ExampleNewSmooth() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := opensimplex2.NewSmooth(seed)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f\n",
+			gen.Eval64(x/smoothness),
+			gen.Eval64(x/smoothness, 0.3),
+			gen.Eval64(x/smoothness, 0.3, 0.6),
+			gen.Eval64(x/smoothness, 0.3, 0.6, 0.9),
+		)
+	}
+
+	// Output:
+	// 0.3302;-0.0899;0.2153;-0.0665
+	// 0.4984;-0.0703;0.3820;-0.1152
+	// 0.4266;-0.1330;0.4301;-0.2042
+}
+
+// For terrains or animations, use ImproveXY orientation to improve the XY slices
+// of 3D noise where Z is the height or the time.
+func ExampleOrientation() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := opensimplex2.New(seed)
+	gen.Orientation = opensimplex2.ImproveXY
+
+	for frame := 0.; frame < 3; frame++ {
+		fmt.Printf("%0.4f\n", gen.Eval64(0.1, 0.2, frame/smoothness))
+	}
+
+	// Output:
+	// 0.0602
+	// -0.2582
+	// -0.4418
+}
@@ -0,0 +1,162 @@
+package opensimplex2
+
+import "math"
+
+// Primes and constants for hashing the lattice vertices.
+const (
+	primeX         = 0x5205402B9270C86F
+	primeY         = 0x598CD327003817B5
+	primeZ         = 0x5BCC226E9FA0BACB
+	primeW         = 0x56CC5227E58F554B
+	hashMultiplier = 0x53A3F72DEEC546F5
+	seedFlip3D     = -0x52D547B2E96ED629
+)
+
+// Number of the gradients in the lookup table of each dimension as an exponent
+// of 2. The gradient sets are repeated to fill the tables.
+const (
+	nGrads2DExponent = 7
+	nGrads3DExponent = 8
+	nGrads4DExponent = 9
+	nGrads2D         = 1 << nGrads2DExponent
+	nGrads3D         = 1 << nGrads3DExponent
+	nGrads4D         = 1 << nGrads4DExponent
+)
+
+// Gradient lookup tables. 2D gradients are stored as pairs and 3D and 4D
+// gradients are stored as quads (the 4th element of 3D is unused) so that the
+// index can be computed with a mask.
+var (
+	gradients2D = newGradients2D()
+	gradients3D = newGradients3D()
+	gradients4D = newGradients4D()
+)
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// grad2 returns the dot product of (dx, dy) and the gradient of the lattice
+// vertex chosen by the hash of the seed and the pre-multiplied vertex coordinates.
+func grad2(seed, xsvp, ysvp int64, dx, dy float64) float64 {
+	hash := (seed ^ xsvp ^ ysvp) * hashMultiplier
+	hash ^= hash >> (64 - nGrads2DExponent + 1)
+	gi := int(hash) & ((nGrads2D - 1) << 1)
+
+	return gradients2D[gi]*dx + gradients2D[gi|1]*dy
+}
+
+// grad3 is the 3-dimensional version of grad2.
+func grad3(seed, xrvp, yrvp, zrvp int64, dx, dy, dz float64) float64 {
+	hash := ((seed ^ xrvp) ^ (yrvp ^ zrvp)) * hashMultiplier
+	hash ^= hash >> (64 - nGrads3DExponent + 2)
+	gi := int(hash) & ((nGrads3D - 1) << 2)
+
+	return gradients3D[gi]*dx + gradients3D[gi|1]*dy + gradients3D[gi|2]*dz
+}
+
+// grad4 is the 4-dimensional version of grad2.
+func grad4(seed, xsvp, ysvp, zsvp, wsvp int64, dx, dy, dz, dw float64) float64 {
+	hash := (seed ^ (xsvp ^ ysvp) ^ (zsvp ^ wsvp)) * hashMultiplier
+	hash ^= hash >> (64 - nGrads4DExponent + 2)
+	gi := int(hash) & ((nGrads4D - 1) << 2)
+
+	return (gradients4D[gi]*dx + gradients4D[gi|1]*dy) + (gradients4D[gi|2]*dz + gradients4D[gi|3]*dw)
+}
+
+// newGradients2D returns the 2D gradient table of the 24 unit vectors evenly
+// spaced by 15 degrees, rotated by 7.5 degrees to avoid the axis alignment.
+func newGradients2D() []float64 {
+	const numDirs = 24
+
+	set := make([]float64, 0, numDirs*2)
+
+	for i := 0; i < numDirs; i++ {
+		rad := (7.5 + 15*float64(i)) * math.Pi / 180
+		set = append(set, math.Cos(rad), math.Sin(rad))
+	}
+
+	return fillTable(set, nGrads2D*2)
+}
+
+// newGradients3D returns the 3D gradient table of the 48 vectors of the same
+// length. 24 of them are the permutations of (±a, ±a, ±1) and the others are
+// the permutations of (±b, ±c, 0).
+func newGradients3D() []float64 {
+	const (
+		a = 2.22474487139
+		b = 3.0862664687972017
+		c = 1.1721513422464978
+	)
+
+	set := make([]float64, 0, 48*4)
+
+	for _, s := range signs(3) {
+		set = append(set,
+			s[0]*a, s[1]*a, s[2], 0,
+			s[0]*a, s[1], s[2]*a, 0,
+			s[0], s[1]*a, s[2]*a, 0,
+		)
+	}
+
+	for _, s := range signs(2) {
+		set = append(set,
+			s[0]*b, s[1]*c, 0, 0,
+			s[0]*c, s[1]*b, 0, 0,
+			s[0]*b, 0, s[1]*c, 0,
+			s[0]*c, 0, s[1]*b, 0,
+			0, s[0]*b, s[1]*c, 0,
+			0, s[0]*c, s[1]*b, 0,
+		)
+	}
+
+	return fillTable(set, nGrads3D*4)
+}
+
+// newGradients4D returns the 4D gradient table of the 32 vectors which are the
+// permutations of (±1, ±1, ±1, 0), the midpoints of the edges of a tesseract.
+func newGradients4D() []float64 {
+	set := make([]float64, 0, 32*4)
+
+	for _, s := range signs(3) {
+		set = append(set,
+			0, s[0], s[1], s[2],
+			s[0], 0, s[1], s[2],
+			s[0], s[1], 0, s[2],
+			s[0], s[1], s[2], 0,
+		)
+	}
+
+	return fillTable(set, nGrads4D*4)
+}
+
+// signs returns all the combinations of ±1 of the given length.
+func signs(length int) [][]float64 {
+	result := make([][]float64, 0, 1<<length)
+
+	for bits := 0; bits < 1<<length; bits++ {
+		s := make([]float64, length)
+
+		for i := range s {
+			s[i] = 1
+			if bits&(1<<i) != 0 {
+				s[i] = -1
+			}
+		}
+
+		result = append(result, s)
+	}
+
+	return result
+}
+
+// fillTable returns a table of the given size filled by repeating the set.
+func fillTable(set []float64, size int) []float64 {
+	table := make([]float64, size)
+
+	for i := range table {
+		table[i] = set[i%len(set)]
+	}
+
+	return table
+}
@@ -0,0 +1,245 @@
+package opensimplex2
+
+import "math"
+
+// Squared radii of the kernels. The ones of OpenSimplex2S are larger and each
+// kernel reaches the neighbouring lattice vertices.
+const (
+	rSquaredF2D = 0.5
+	rSquaredF3D = 0.6
+	rSquaredF4D = 0.6
+	rSquaredS2D = 2. / 3.
+	rSquaredS3D = 0.75
+	rSquaredS4D = 0.8
+)
+
+// Skewing factors of the 4D A4* lattice.
+const (
+	skewA4Star   = 0.30901699437494742410 // (sqrt(5) - 1) / 4
+	unskewA4Star = -0.13819660112501051518
+)
+
+// subdivision is the number of the sub-cells per axis of a skewed unit cell to
+// look up the candidate vertices.
+const subdivision = 4
+
+// Tables of the lattice vertices which may contribute to the points in each
+// sub-cell.
+var (
+	candidatesS2D = newCandidates(2, unskew2D, rSquaredS2D)
+	candidatesF4D = newCandidates(4, unskewA4Star, rSquaredF4D)
+	candidatesS4D = newCandidates(4, unskewA4Star, rSquaredS4D)
+)
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// noise2F returns the unscaled 2-dimensional OpenSimplex2F noise value at the
+// skewed coordinates. Only the 3 vertices of the triangle containing the point
+// can contribute.
+func noise2F(seed int64, xs, ys float64) float64 {
+	xsb, ysb := fastFloor(xs), fastFloor(ys)
+	xi, yi := xs-float64(xsb), ys-float64(ysb)
+
+	// Prime pre-multiplication for the hash.
+	xsbp, ysbp := xsb*primeX, ysb*primeY
+
+	// Unskew.
+	t := (xi + yi) * unskew2D
+	dx0, dy0 := xi+t, yi+t
+
+	value := corner(rSquaredF2D-dx0*dx0-dy0*dy0, grad2(seed, xsbp, ysbp, dx0, dy0))
+
+	dx1, dy1 := dx0-(1+2*unskew2D), dy0-(1+2*unskew2D)
+	value += corner(rSquaredF2D-dx1*dx1-dy1*dy1, grad2(seed, xsbp+primeX, ysbp+primeY, dx1, dy1))
+
+	if dy0 > dx0 {
+		dx2, dy2 := dx0-unskew2D, dy0-(unskew2D+1)
+
+		return value + corner(rSquaredF2D-dx2*dx2-dy2*dy2, grad2(seed, xsbp, ysbp+primeY, dx2, dy2))
+	}
+
+	dx2, dy2 := dx0-(unskew2D+1), dy0-unskew2D
+
+	return value + corner(rSquaredF2D-dx2*dx2-dy2*dy2, grad2(seed, xsbp+primeX, ysbp, dx2, dy2))
+}
+
+// noise2S returns the unscaled 2-dimensional OpenSimplex2S noise value at the
+// skewed coordinates.
+func noise2S(seed int64, xs, ys float64) float64 {
+	xsb, ysb := fastFloor(xs), fastFloor(ys)
+	xi, yi := xs-float64(xsb), ys-float64(ysb)
+	xsbp, ysbp := xsb*primeX, ysb*primeY
+
+	value := 0.
+
+	for _, c := range candidatesS2D[subCell(xi)+subCell(yi)*subdivision] {
+		t := (xi + yi - c[0] - c[1]) * unskew2D
+		dx, dy := xi-c[0]+t, yi-c[1]+t
+
+		a := rSquaredS2D - dx*dx - dy*dy
+		if a <= 0 {
+			continue
+		}
+
+		value += corner(a, grad2(seed, xsbp+int64(c[0])*primeX, ysbp+int64(c[1])*primeY, dx, dy))
+	}
+
+	return value
+}
+
+// noise3 returns the unscaled 3-dimensional OpenSimplex2 noise value at the
+// rotated coordinates with the given squared kernel radius.
+//
+// The BCC lattice is evaluated as two cubic lattices offset by half a unit. Since
+// the kernel radius is less than 1, only the 8 vertices of the cube containing
+// the point can contribute on each lattice.
+func noise3(seed int64, xr, yr, zr, rSquared float64) float64 {
+	value := 0.
+
+	for l := 0; l < 2; l++ {
+		xrb, yrb, zrb := fastFloor(xr), fastFloor(yr), fastFloor(zr)
+		xri, yri, zri := xr-float64(xrb), yr-float64(yrb), zr-float64(zrb)
+		xrbp, yrbp, zrbp := xrb*primeX, yrb*primeY, zrb*primeZ
+
+		for v := 0; v < 8; v++ {
+			i, j, k := int64(v&1), int64(v>>1&1), int64(v>>2&1)
+			dx, dy, dz := xri-float64(i), yri-float64(j), zri-float64(k)
+
+			a := rSquared - dx*dx - dy*dy - dz*dz
+			if a <= 0 {
+				continue
+			}
+
+			value += corner(a, grad3(seed, xrbp+i*primeX, yrbp+j*primeY, zrbp+k*primeZ, dx, dy, dz))
+		}
+
+		// Move on to the other lattice copy.
+		xr, yr, zr = xr+0.5, yr+0.5, zr+0.5
+		seed ^= seedFlip3D
+	}
+
+	return value
+}
+
+// noise4 returns the unscaled 4-dimensional OpenSimplex2 noise value at the
+// (rotated) coordinates with the given squared kernel radius and the candidate
+// table of it.
+func noise4(seed int64, x, y, z, w, rSquared float64, candidates [][][4]float64) float64 {
+	// Skew to the A4* lattice.
+	s := (x + y + z + w) * skewA4Star
+	xs, ys, zs, ws := x+s, y+s, z+s, w+s
+
+	xsb, ysb, zsb, wsb := fastFloor(xs), fastFloor(ys), fastFloor(zs), fastFloor(ws)
+	xsi, ysi, zsi, wsi := xs-float64(xsb), ys-float64(ysb), zs-float64(zsb), ws-float64(wsb)
+	xsbp, ysbp, zsbp, wsbp := xsb*primeX, ysb*primeY, zsb*primeZ, wsb*primeW
+
+	index := subCell(xsi) + subdivision*(subCell(ysi)+subdivision*(subCell(zsi)+subdivision*subCell(wsi)))
+	value := 0.
+
+	for _, c := range candidates[index] {
+		t := (xsi + ysi + zsi + wsi - c[0] - c[1] - c[2] - c[3]) * unskewA4Star
+		dx, dy, dz, dw := xsi-c[0]+t, ysi-c[1]+t, zsi-c[2]+t, wsi-c[3]+t
+
+		a := rSquared - (dx*dx + dy*dy) - (dz*dz + dw*dw)
+		if a <= 0 {
+			continue
+		}
+
+		value += corner(a, grad4(
+			seed,
+			xsbp+int64(c[0])*primeX, ysbp+int64(c[1])*primeY, zsbp+int64(c[2])*primeZ, wsbp+int64(c[3])*primeW,
+			dx, dy, dz, dw,
+		))
+	}
+
+	return value
+}
+
+// subCell returns the index of the sub-cell on an axis from the fractional part
+// of the skewed coordinate.
+func subCell(frac float64) int {
+	i := int(frac * subdivision)
+	if i >= subdivision {
+		// Rounding error of the fractional part close to 1.
+		return subdivision - 1
+	}
+
+	return i
+}
+
+// newCandidates returns the table of the lattice vertices, as offsets from the
+// base vertex of the skewed unit cell, which may be in the kernel radius from a
+// point in each sub-cell of the given dimension.
+//
+// A vertex is a candidate of a sub-cell if its distance to the center of the
+// sub-cell, minus the distance from the center to the farthest corner of the
+// sub-cell, is less than the kernel radius. Since it bounds the sub-cell with a
+// sphere, it is a superset of the contributing vertices.
+func newCandidates(dim int, unskew, rSquared float64) [][][4]float64 {
+	// toReal returns the unskewed coordinates of the skewed ones.
+	toReal := func(q [4]float64) [4]float64 {
+		s := 0.
+		for i := 0; i < dim; i++ {
+			s += q[i]
+		}
+
+		for i := 0; i < dim; i++ {
+			q[i] += s * unskew
+		}
+
+		return q
+	}
+
+	distance := func(a, b [4]float64) float64 {
+		sum := 0.
+		for i := 0; i < dim; i++ {
+			sum += (a[i] - b[i]) * (a[i] - b[i])
+		}
+
+		return math.Sqrt(sum)
+	}
+
+	// The range of the offsets to search. It is wide enough for the kernels of
+	// the unit lattices.
+	const minOffset, maxOffset = -2, 3
+
+	span := maxOffset - minOffset + 1
+	numCells := int(math.Pow(subdivision, float64(dim)))
+	numOffsets := int(math.Pow(float64(span), float64(dim)))
+	table := make([][][4]float64, numCells)
+
+	for cell := range table {
+		var center [4]float64
+
+		for i, rest := 0, cell; i < dim; i, rest = i+1, rest/subdivision {
+			center[i] = (float64(rest%subdivision) + 0.5) / subdivision
+		}
+
+		radius := 0.
+
+		for m := 0; m < 1<<dim; m++ {
+			var vertex [4]float64
+			for i := 0; i < dim; i++ {
+				vertex[i] = center[i] + (float64(m>>i&1)-0.5)/subdivision
+			}
+
+			radius = math.Max(radius, distance(toReal(center), toReal(vertex)))
+		}
+
+		for m := 0; m < numOffsets; m++ {
+			var offset [4]float64
+
+			for i, rest := 0, m; i < dim; i, rest = i+1, rest/span {
+				offset[i] = float64(rest%span + minOffset)
+			}
+
+			if distance(toReal(center), toReal(offset))-radius < math.Sqrt(rSquared) {
+				table[cell] = append(table[cell], offset)
+			}
+		}
+	}
+
+	return table
+}
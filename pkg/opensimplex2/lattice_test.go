@@ -0,0 +1,167 @@
+package opensimplex2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestScale checks the scaling factors by maximizing the sum of the kernels over
+// a unit cell, where every vertex has the gradient of the largest dot product.
+func TestScale(t *testing.T) {
+	grads2D := make([]float64, 0, 24*4)
+	for i := 0; i < 24; i++ {
+		grads2D = append(grads2D, gradients2D[2*i], gradients2D[2*i+1], 0, 0)
+	}
+
+	// The BCC lattice is the two cubic lattices offset by half a unit.
+	offsets3D := append(latticeOffsets(3, 0), latticeOffsets(3, 0.5)...)
+
+	for _, test := range []struct {
+		name     string
+		grads    []float64
+		offsets  [][4]float64
+		unskew   float64
+		rSquared float64
+		scale    float64
+		dim      int
+		res      int
+	}{
+		{"F2D", grads2D, latticeOffsets(2, 0), unskew2D, rSquaredF2D, scaleF2D, 2, 24},
+		{"S2D", grads2D, latticeOffsets(2, 0), unskew2D, rSquaredS2D, scaleS2D, 2, 24},
+		{"F3D", gradients3D[:48*4], offsets3D, 0, rSquaredF3D, scaleF3D, 3, 24},
+		{"S3D", gradients3D[:48*4], offsets3D, 0, rSquaredS3D, scaleS3D, 3, 24},
+		{"F4D", gradients4D[:32*4], latticeOffsets(4, 0), unskewA4Star, rSquaredF4D, scaleF4D, 4, 10},
+		{"S4D", gradients4D[:32*4], latticeOffsets(4, 0), unskewA4Star, rSquaredS4D, scaleS4D, 4, 10},
+	} {
+		maxSum := maximizeKernels(test.dim, test.res, test.unskew, test.rSquared, test.offsets, test.grads)
+
+		require.InEpsilon(t, 1/test.scale, maxSum, 1e-12, test.name)
+	}
+}
+
+func TestRotate4(t *testing.T) {
+	p := [4]float64{0.3, -1.2, 2.5, 0.7}
+	q := [4]float64{-0.4, 0.9, 1.1, -2.3}
+
+	distance := func(a, b [4]float64) float64 {
+		return math.Sqrt((a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2]) + (a[3]-b[3])*(a[3]-b[3]))
+	}
+
+	for _, o := range []Orientation{Fallback, ImproveXY, ImproveXZ} {
+		var rp, rq [4]float64
+
+		rp[0], rp[1], rp[2], rp[3] = rotate4(o, p[0], p[1], p[2], p[3])
+		rq[0], rq[1], rq[2], rq[3] = rotate4(o, q[0], q[1], q[2], q[3])
+
+		// All the orientations are rotations, which keep the distances and thus
+		// the range of the noise.
+		require.InDelta(t, distance(p, q), distance(rp, rq), 1e-12, "orientation: %v", o)
+
+		if o == Fallback {
+			require.InDeltaSlice(t, p[:], rp[:], 1e-12, "fallback should not rotate the coordinates")
+		}
+	}
+}
+
+// maximizeKernels returns the maximum of the sum of the kernels in the skewed
+// unit cell of dim dimensions, searched by a grid of the resolution res and
+// refined by the pattern search.
+func maximizeKernels(dim, res int, unskew, rSquared float64, offsets [][4]float64, grads []float64) float64 {
+	toReal := func(q [4]float64) [4]float64 {
+		s := (q[0] + q[1] + q[2] + q[3]) * unskew
+
+		for i := 0; i < dim; i++ {
+			q[i] += s
+		}
+
+		return q
+	}
+
+	vertices := make([][4]float64, len(offsets))
+	for i, o := range offsets {
+		vertices[i] = toReal(o)
+	}
+
+	sum := func(q [4]float64) float64 {
+		p := toReal(q)
+		value := 0.
+
+		for _, v := range vertices {
+			d := [4]float64{p[0] - v[0], p[1] - v[1], p[2] - v[2], p[3] - v[3]}
+
+			a := rSquared - (d[0]*d[0] + d[1]*d[1]) - (d[2]*d[2] + d[3]*d[3])
+			if a <= 0 {
+				continue
+			}
+
+			best := math.Inf(-1)
+			for g := 0; g < len(grads); g += 4 {
+				best = math.Max(best, grads[g]*d[0]+grads[g+1]*d[1]+grads[g+2]*d[2]+grads[g+3]*d[3])
+			}
+
+			value += corner(a, best)
+		}
+
+		return value
+	}
+
+	var bestQ [4]float64
+
+	best := math.Inf(-1)
+	numGrid := int(math.Pow(float64(res+1), float64(dim)))
+
+	for idx := 0; idx < numGrid; idx++ {
+		var q [4]float64
+
+		for i, k := 0, idx; i < dim; i, k = i+1, k/(res+1) {
+			q[i] = float64(k%(res+1)) / float64(res)
+		}
+
+		if v := sum(q); v > best {
+			best, bestQ = v, q
+		}
+	}
+
+	numDir := int(math.Pow(3, float64(dim)))
+
+	for step := 1 / float64(res); step > 1e-15; {
+		improved := false
+
+		for dir := 0; dir < numDir; dir++ {
+			q := bestQ
+
+			for i, k := 0, dir; i < dim; i, k = i+1, k/3 {
+				q[i] += float64(k%3-1) * step
+			}
+
+			if v := sum(q); v > best {
+				best, bestQ, improved = v, q, true
+			}
+		}
+
+		if !improved {
+			step /= 2
+		}
+	}
+
+	return best
+}
+
+// latticeOffsets returns the offsets of the vertices from -2 to 3 on each axis of
+// dim dimensions, shifted by shift.
+func latticeOffsets(dim int, shift float64) [][4]float64 {
+	const minOffset, maxOffset = -2, 3
+
+	span := maxOffset - minOffset + 1
+	result := make([][4]float64, int(math.Pow(float64(span), float64(dim))))
+
+	for m := range result {
+		for i, rest := 0, m; i < dim; i, rest = i+1, rest/span {
+			result[m][i] = float64(rest%span+minOffset) + shift
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,384 @@
+/*
+Package opensimplex2 is a native implementation of OpenSimplex2 noise which
+implements github.com/KEINOS/go-noise/noise interface.
+
+OpenSimplex2 is the successor of OpenSimplex by Kurt Spencer (KdotJPG). It has less
+directional artifacts and is faster than the original OpenSimplex. It comes in
+two variants:
+
+  - OpenSimplex2F (New): the faster one, with the smaller kernels.
+  - OpenSimplex2S (NewSmooth): the smoother one, with the larger kernels.
+
+It supports 1 to 4 dimensions. 1D is a slice of 2D at y = 0. The noise values
+are in the range of [-1, 1].
+
+This implementation follows the lattices and the hashing of the reference
+implementation, but it is not bit-compatible with it.
+*/
+package opensimplex2
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// Skewing, unskewing and rotation factors.
+const (
+	skew2D     = 0.366025403784439
+	unskew2D   = -0.21132486540518713
+	root3Over3 = 0.577350269189626
+
+	fallbackRotate3D       = 2. / 3.
+	rotate3DOrthogonalizer = unskew2D
+
+	skew4D   = -0.138196601125011
+	unskew4D = 0.309016994374947
+)
+
+// Scaling factors of each variant and dimension to fit the sum of the
+// contributions in the range of [-1, 1]. They are the reciprocals of the maximum
+// absolute values of the sum, where every vertex in the kernel radius has the
+// gradient of the largest dot product.
+//
+// The 2D and 3D gradient sets and kernels are the same as the ones of the
+// reference implementation (https://github.com/KdotJPG/OpenSimplex2), so are
+// the maximums, which are the normalizers of it. The 4D gradient set is the one
+// of this package and the maximums are found by maximizing the sum numerically.
+// TestScale checks all of them.
+const (
+	scaleF2D = 1 / 0.01001634121365712
+	scaleF3D = 1 / 0.07969837668935331
+	scaleF4D = 1 / 0.036730023916654926
+	scaleS2D = 1 / 0.05481866495625118
+	scaleS3D = 1 / 0.2781926117527186
+	scaleS4D = 1 / 0.18096421768559132
+)
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a seeded OpenSimplex2F noise instance.
+func New(seed int64) *Generator {
+	return &Generator{
+		Seed: seed,
+	}
+}
+
+// NewSmooth returns a seeded OpenSimplex2S noise instance.
+func NewSmooth(seed int64) *Generator {
+	return &Generator{
+		Seed:   seed,
+		Smooth: true,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Orientation
+// ----------------------------------------------------------------------------
+
+// Orientation is the orientation of the lattice for 3D and 4D noise. It does not
+// affect 1D and 2D noise.
+type Orientation int
+
+const (
+	// Fallback is the default orientation which rotates the lattice to look
+	// familiar. None of the axes is treated specially. In 4D, the lattice is not
+	// rotated as the reference implementation.
+	Fallback Orientation = iota
+	// ImproveXY improves the visual isotropy of the XY planes. Use it if Z is the
+	// "different" axis such as the height of a terrain or the time of an
+	// animation. In 4D, XYZ is improved as well and W is the different one.
+	ImproveXY
+	// ImproveXZ improves the visual isotropy of the XZ planes. Use it if Y is the
+	// "different" axis such as the height of a terrain. In 4D, XYZ is improved as
+	// well and W is the different one.
+	ImproveXZ
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for OpenSimplex2 noise. It is an
+// implementation of Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines. But the fields must not be changed
+// while evaluating.
+type Generator struct {
+	// Seed holds the seed value for the noise.
+	Seed int64
+	// Orientation is the orientation of the lattice for 3D and 4D noise.
+	Orientation Orientation
+	// Smooth selects OpenSimplex2S if true, otherwise OpenSimplex2F.
+	Smooth bool
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 OpenSimplex2 noise value for the given coordinates.
+// It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 OpenSimplex2 noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 OpenSimplex2 noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.Eval1D64(float64(x)))
+}
+
+// Eval2D32 returns a float32 OpenSimplex2 noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.Eval2D64(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 OpenSimplex2 noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.Eval3D64(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 OpenSimplex2 noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.Eval4D64(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 OpenSimplex2 noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return n.Eval2D64(x, 0)
+}
+
+// Eval2D64 returns a float64 OpenSimplex2 noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	// Skew to the triangular lattice.
+	s := skew2D * (x + y)
+	xs, ys := x+s, y+s
+
+	if n.Smooth {
+		return clamp(scaleS2D * noise2S(n.Seed, xs, ys))
+	}
+
+	return clamp(scaleF2D * noise2F(n.Seed, xs, ys))
+}
+
+// Eval3D64 returns a float64 OpenSimplex2 noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	xr, yr, zr := rotate3D(n.Orientation, x, y, z)
+
+	if n.Smooth {
+		return clamp(scaleS3D * noise3(n.Seed, xr, yr, zr, rSquaredS3D))
+	}
+
+	return clamp(scaleF3D * noise3(n.Seed, xr, yr, zr, rSquaredF3D))
+}
+
+// Eval4D64 returns a float64 OpenSimplex2 noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	xr, yr, zr, wr := rotate4(n.Orientation, x, y, z, w)
+
+	if n.Smooth {
+		return clamp(scaleS4D * noise4(n.Seed, xr, yr, zr, wr, rSquaredS4D, candidatesS4D))
+	}
+
+	return clamp(scaleF4D * noise4(n.Seed, xr, yr, zr, wr, rSquaredF4D, candidatesF4D))
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in OpenSimplex2 type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in OpenSimplex2 type")
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// rotate3D returns the coordinates rotated to the BCC lattice by the orientation.
+// The rotations are orthonormal, not skew transforms.
+func rotate3D(o Orientation, x, y, z float64) (xr, yr, zr float64) {
+	switch o {
+	case ImproveXY:
+		// Z points up the main diagonal of the lattice and the XY planes are moved
+		// far out of alignment with the cube faces.
+		xy := x + y
+		s2 := xy * rotate3DOrthogonalizer
+		zz := z * root3Over3
+
+		return x + s2 + zz, y + s2 + zz, xy*-root3Over3 + zz
+	case ImproveXZ:
+		xz := x + z
+		s2 := xz * rotate3DOrthogonalizer
+		yy := y * root3Over3
+
+		return x + s2 + yy, xz*-root3Over3 + yy, z + s2 + yy
+	case Fallback:
+	}
+
+	r := fallbackRotate3D * (x + y + z)
+
+	return r - x, r - y, r - z
+}
+
+// rotate4 returns the coordinates rotated by the orientation. The rotations are
+// given as skew transforms to the A4 lattice as the reference implementation
+// does, thus they are unskewed before returning.
+//
+// Fallback is not rotated but only skewed as the reference does. So it returns
+// the same coordinates, up to the rounding error.
+func rotate4(o Orientation, x, y, z, w float64) (xr, yr, zr, wr float64) {
+	const (
+		rotate4DXY = -0.21132486540518699998
+		rotate4DZ  = 0.28867513459481294226
+		rotate4DW  = 0.2236067977499788
+		rotate4DXZ = -0.57735026918962599998
+		rotate4DWZ = -0.866025403784439
+	)
+
+	switch o {
+	case ImproveXY:
+		xy := x + y
+		s2 := xy * rotate4DXY
+		zz := z * rotate4DZ
+		ww := w * rotate4DW
+
+		return unskew4(x+(zz+ww+s2), y+(zz+ww+s2), xy*rotate4DXZ+(zz+ww), z*rotate4DWZ+ww)
+	case ImproveXZ:
+		xz := x + z
+		s2 := xz * rotate4DXY
+		yy := y * rotate4DZ
+		ww := w * rotate4DW
+
+		return unskew4(x+(yy+ww+s2), xz*rotate4DXZ+(yy+ww), z+(yy+ww+s2), y*rotate4DWZ+ww)
+	case Fallback:
+	}
+
+	s := (x + y + z + w) * skew4D
+
+	return unskew4(x+s, y+s, z+s, w+s)
+}
+
+// unskew4 returns the coordinates of the A4 lattice skewed ones.
+func unskew4(xs, ys, zs, ws float64) (x, y, z, w float64) {
+	s := (xs + ys + zs + ws) * unskew4D
+
+	return xs + s, ys + s, zs + s, ws + s
+}
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. OpenSimplex2 supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// clamp limits v to the range of [-1, 1]. The scaled sum never exceeds it, so it
+// only guards the rounding error of the last bit.
+func clamp(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
+
+// corner returns the contribution of a lattice vertex. a is the attenuation of
+// the distance from the vertex and g is the gradient dot product.
+func corner(a, g float64) float64 {
+	if a <= 0 {
+		return 0
+	}
+
+	a *= a
+
+	return a * a * g
+}
+
+// fastFloor returns the floor of x as an int64.
+func fastFloor(x float64) int64 {
+	return int64(math.Floor(x))
+}
+
+// fastRound returns x rounded half away from zero as an int64.
+func fastRound(x float64) int64 {
+	return int64(math.Round(x))
+}
+
+// negSign returns -1 if x is positive or zero, otherwise 1.
+func negSign(x float64) float64 {
+	if x >= 0 {
+		return -1
+	}
+
+	return 1
+}
@@ -0,0 +1,256 @@
+package opensimplex2_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise/pkg/opensimplex2"
+	"github.com/stretchr/testify/require"
+)
+
+// variants returns the generators of both variants with all the orientations.
+func variants(seed int64) map[string]*opensimplex2.Generator {
+	result := map[string]*opensimplex2.Generator{}
+
+	for name, o := range map[string]opensimplex2.Orientation{
+		"fallback":   opensimplex2.Fallback,
+		"improve_xy": opensimplex2.ImproveXY,
+		"improve_xz": opensimplex2.ImproveXZ,
+	} {
+		fast, smooth := opensimplex2.New(seed), opensimplex2.NewSmooth(seed)
+		fast.Orientation, smooth.Orientation = o, o
+
+		result["fast/"+name] = fast
+		result["smooth/"+name] = smooth
+	}
+
+	return result
+}
+
+func TestGenerator_is_in_range(t *testing.T) {
+	for name, gen := range variants(100) {
+		for d := 1; d <= 4; d++ {
+			minV, maxV := math.Inf(1), math.Inf(-1)
+
+			for i := 0; i < 20000; i++ {
+				f := float64(i)
+				v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+				minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+			}
+
+			require.GreaterOrEqual(t, minV, -1., "%s %dD should be in the range of -1 to 1", name, d)
+			require.LessOrEqual(t, maxV, 1., "%s %dD should be in the range of -1 to 1", name, d)
+			require.Less(t, minV, -0.5, "%s %dD should be spread in the range", name, d)
+			require.Greater(t, maxV, 0.5, "%s %dD should be spread in the range", name, d)
+		}
+	}
+}
+
+func TestGenerator_continuous(t *testing.T) {
+	const (
+		eps   = 1e-7
+		delta = 1e-4
+	)
+
+	for name, gen := range variants(100) {
+		for i := 0; i < 1000; i++ {
+			f := float64(i) * 0.173
+
+			require.InDelta(t, gen.Eval64(f), gen.Eval64(f+eps), delta, name)
+			require.InDelta(t, gen.Eval64(f, -f), gen.Eval64(f+eps, -f), delta, name)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f), gen.Eval64(f, -f+eps, 2*f), delta, name)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f, 3*f), gen.Eval64(f, -f, 2*f, 3*f+eps), delta, name)
+		}
+	}
+}
+
+func TestGenerator_seed(t *testing.T) {
+	for name, gen := range variants(100) {
+		before := gen.Eval64(0.3, 0.6, 0.9)
+
+		gen.Seed = 101
+
+		require.NotEqual(t, before, gen.Eval64(0.3, 0.6, 0.9), "%s: changing the Seed should change the noise value", name)
+	}
+
+	require.Equal(t, opensimplex2.New(100).Eval64(0.3, 0.6), opensimplex2.New(100).Eval64(0.3, 0.6),
+		"same seed should return the same value")
+}
+
+func TestGenerator_variants_differ(t *testing.T) {
+	fast, smooth := opensimplex2.New(100), opensimplex2.NewSmooth(100)
+
+	require.NotEqual(t, fast.Eval64(0.3, 0.6), smooth.Eval64(0.3, 0.6))
+	require.NotEqual(t, fast.Eval64(0.3, 0.6, 0.9), smooth.Eval64(0.3, 0.6, 0.9))
+	require.NotEqual(t, fast.Eval64(0.3, 0.6, 0.9, 1.2), smooth.Eval64(0.3, 0.6, 0.9, 1.2))
+}
+
+func TestGenerator_Orientation(t *testing.T) {
+	fallback, improved := opensimplex2.New(100), opensimplex2.New(100)
+	improved.Orientation = opensimplex2.ImproveXY
+
+	require.Equal(t, fallback.Eval64(0.3, 0.6), improved.Eval64(0.3, 0.6),
+		"orientation should not affect 2D noise")
+	require.NotEqual(t, fallback.Eval64(0.3, 0.6, 0.9), improved.Eval64(0.3, 0.6, 0.9),
+		"orientation should affect 3D noise")
+	require.NotEqual(t, fallback.Eval64(0.3, 0.6, 0.9, 1.2), improved.Eval64(0.3, 0.6, 0.9, 1.2),
+		"orientation should affect 4D noise")
+}
+
+// TestGenerator_reference_values checks the values against the output of
+// testdata/opensimplex2.py, which sums up every lattice vertex in the kernel
+// radius by brute force.
+func TestGenerator_reference_values(t *testing.T) {
+	for _, test := range []struct {
+		smooth      bool
+		orientation opensimplex2.Orientation
+		seed        int64
+		point       []float64
+		expect      float64
+	}{
+		{false, opensimplex2.Fallback, 0, []float64{0.3}, 0.7859456890779581},
+		{false, opensimplex2.Fallback, 0, []float64{-12.7}, -0.19580943875072218},
+		{false, opensimplex2.Fallback, 0, []float64{0.3, 0.6}, 0.7384496814425009},
+		{false, opensimplex2.Fallback, 0, []float64{-12.7, 45.1}, -0.7748703141300086},
+		{false, opensimplex2.Fallback, 0, []float64{0.3, 0.6, 0.9}, 0.11649833082402952},
+		{false, opensimplex2.Fallback, 0, []float64{-12.7, 45.1, -7.3}, -0.1568757902084029},
+		{false, opensimplex2.Fallback, 0, []float64{0.3, 0.6, 0.9, 1.2}, 0.08599980857287887},
+		{false, opensimplex2.Fallback, 0, []float64{-12.7, 45.1, -7.3, 3.9}, 0.30636690273605366},
+		{false, opensimplex2.Fallback, 100, []float64{0.3}, 0.7554885134808142},
+		{false, opensimplex2.Fallback, 100, []float64{-12.7}, -0.14062273824370958},
+		{false, opensimplex2.Fallback, 100, []float64{0.3, 0.6}, -0.838385105948539},
+		{false, opensimplex2.Fallback, 100, []float64{-12.7, 45.1}, -0.6960929068403883},
+		{false, opensimplex2.Fallback, 100, []float64{0.3, 0.6, 0.9}, 0.19699814433173482},
+		{false, opensimplex2.Fallback, 100, []float64{-12.7, 45.1, -7.3}, 0.29386553452496883},
+		{false, opensimplex2.Fallback, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.41729305860777316},
+		{false, opensimplex2.Fallback, 100, []float64{-12.7, 45.1, -7.3, 3.9}, 0.1960613771204076},
+		{false, opensimplex2.ImproveXY, 0, []float64{0.3, 0.6, 0.9}, -0.43360136492253726},
+		{false, opensimplex2.ImproveXY, 0, []float64{-12.7, 45.1, -7.3}, 0.15618690162690416},
+		{false, opensimplex2.ImproveXY, 0, []float64{0.3, 0.6, 0.9, 1.2}, 0.05786328476439023},
+		{false, opensimplex2.ImproveXY, 0, []float64{-12.7, 45.1, -7.3, 3.9}, -0.3116001180801648},
+		{false, opensimplex2.ImproveXY, 100, []float64{0.3, 0.6, 0.9}, -0.5359849892722123},
+		{false, opensimplex2.ImproveXY, 100, []float64{-12.7, 45.1, -7.3}, -0.2928294753279589},
+		{false, opensimplex2.ImproveXY, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.13740040706844725},
+		{false, opensimplex2.ImproveXY, 100, []float64{-12.7, 45.1, -7.3, 3.9}, -0.40258852213785534},
+		{false, opensimplex2.ImproveXZ, 0, []float64{0.3, 0.6, 0.9}, -0.10298782197930058},
+		{false, opensimplex2.ImproveXZ, 0, []float64{-12.7, 45.1, -7.3}, 0.47679722719692696},
+		{false, opensimplex2.ImproveXZ, 0, []float64{0.3, 0.6, 0.9, 1.2}, -0.3668120584028032},
+		{false, opensimplex2.ImproveXZ, 0, []float64{-12.7, 45.1, -7.3, 3.9}, 0.16053648499571482},
+		{false, opensimplex2.ImproveXZ, 100, []float64{0.3, 0.6, 0.9}, 0.1616406841586827},
+		{false, opensimplex2.ImproveXZ, 100, []float64{-12.7, 45.1, -7.3}, 0.46878569095339173},
+		{false, opensimplex2.ImproveXZ, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.3598325100989896},
+		{false, opensimplex2.ImproveXZ, 100, []float64{-12.7, 45.1, -7.3, 3.9}, -0.6508739999673955},
+		{true, opensimplex2.Fallback, 0, []float64{0.3}, 0.5431841494841719},
+		{true, opensimplex2.Fallback, 0, []float64{-12.7}, -0.26731081337612095},
+		{true, opensimplex2.Fallback, 0, []float64{0.3, 0.6}, 0.6331539222344074},
+		{true, opensimplex2.Fallback, 0, []float64{-12.7, 45.1}, -0.4442576692076665},
+		{true, opensimplex2.Fallback, 0, []float64{0.3, 0.6, 0.9}, 0.15357940936681574},
+		{true, opensimplex2.Fallback, 0, []float64{-12.7, 45.1, -7.3}, -0.16228889987728504},
+		{true, opensimplex2.Fallback, 0, []float64{0.3, 0.6, 0.9, 1.2}, 0.1455596119301939},
+		{true, opensimplex2.Fallback, 0, []float64{-12.7, 45.1, -7.3, 3.9}, 0.28778737178370517},
+		{true, opensimplex2.Fallback, 100, []float64{0.3}, 0.42662723123707325},
+		{true, opensimplex2.Fallback, 100, []float64{-12.7}, -0.16366056901383438},
+		{true, opensimplex2.Fallback, 100, []float64{0.3, 0.6}, -0.5347781121068322},
+		{true, opensimplex2.Fallback, 100, []float64{-12.7, 45.1}, -0.4300183629159797},
+		{true, opensimplex2.Fallback, 100, []float64{0.3, 0.6, 0.9}, 0.18579056164696942},
+		{true, opensimplex2.Fallback, 100, []float64{-12.7, 45.1, -7.3}, 0.3878448173071651},
+		{true, opensimplex2.Fallback, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.5599725354587478},
+		{true, opensimplex2.Fallback, 100, []float64{-12.7, 45.1, -7.3, 3.9}, 0.20463155100977026},
+		{true, opensimplex2.ImproveXY, 0, []float64{0.3, 0.6, 0.9}, -0.39491225015751763},
+		{true, opensimplex2.ImproveXY, 0, []float64{-12.7, 45.1, -7.3}, 0.0566658139856517},
+		{true, opensimplex2.ImproveXY, 0, []float64{0.3, 0.6, 0.9, 1.2}, 0.05386590957500948},
+		{true, opensimplex2.ImproveXY, 0, []float64{-12.7, 45.1, -7.3, 3.9}, -0.3168097562736079},
+		{true, opensimplex2.ImproveXY, 100, []float64{0.3, 0.6, 0.9}, -0.4353363676925258},
+		{true, opensimplex2.ImproveXY, 100, []float64{-12.7, 45.1, -7.3}, -0.19980499744327596},
+		{true, opensimplex2.ImproveXY, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.09222131879294543},
+		{true, opensimplex2.ImproveXY, 100, []float64{-12.7, 45.1, -7.3, 3.9}, -0.29029829323618866},
+		{true, opensimplex2.ImproveXZ, 0, []float64{0.3, 0.6, 0.9}, -0.1271778450005008},
+		{true, opensimplex2.ImproveXZ, 0, []float64{-12.7, 45.1, -7.3}, 0.5239981589529202},
+		{true, opensimplex2.ImproveXZ, 0, []float64{0.3, 0.6, 0.9, 1.2}, -0.5149109251684636},
+		{true, opensimplex2.ImproveXZ, 0, []float64{-12.7, 45.1, -7.3, 3.9}, 0.17813841421898904},
+		{true, opensimplex2.ImproveXZ, 100, []float64{0.3, 0.6, 0.9}, 0.19379651571291895},
+		{true, opensimplex2.ImproveXZ, 100, []float64{-12.7, 45.1, -7.3}, 0.622022462944774},
+		{true, opensimplex2.ImproveXZ, 100, []float64{0.3, 0.6, 0.9, 1.2}, -0.3904651448533414},
+		{true, opensimplex2.ImproveXZ, 100, []float64{-12.7, 45.1, -7.3, 3.9}, -0.605396047205398},
+	} {
+		gen := opensimplex2.New(test.seed)
+		gen.Smooth, gen.Orientation = test.smooth, test.orientation
+
+		require.InDelta(t, test.expect, gen.Eval64(test.point...), 1e-12,
+			"smooth: %v, orientation: %v, seed: %v, point: %v", test.smooth, test.orientation, test.seed, test.point)
+	}
+}
+
+func TestGenerator_float32(t *testing.T) {
+	for name, gen := range variants(100) {
+		require.InDelta(t, gen.Eval64(0.1), gen.Eval32(0.1), 1e-6, name)
+		require.InDelta(t, gen.Eval64(0.1, 0.2), gen.Eval32(0.1, 0.2), 1e-6, name)
+		require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3), gen.Eval32(0.1, 0.2, 0.3), 1e-6, name)
+		require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), gen.Eval32(0.1, 0.2, 0.3, 0.4), 1e-6, name)
+	}
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := opensimplex2.New(100)
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	for name, gen := range variants(100) {
+		require.Zero(t, testing.AllocsPerRun(100, func() {
+			_ = gen.Eval1D64(0.1)
+			_ = gen.Eval2D64(0.1, 0.2)
+			_ = gen.Eval3D64(0.1, 0.2, 0.3)
+			_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+			_ = gen.Eval1D32(0.1)
+			_ = gen.Eval2D32(0.1, 0.2)
+			_ = gen.Eval3D32(0.1, 0.2, 0.3)
+			_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+		}), name)
+	}
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := opensimplex2.New(100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
+
+// ----------------------------------------------------------------------------
+//  Benchmarks
+// ----------------------------------------------------------------------------
+
+func BenchmarkGenerator_Eval3D64_fast(b *testing.B) {
+	gen := opensimplex2.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = gen.Eval3D64(float64(i)*0.01, 0.2, 0.3)
+	}
+}
+
+func BenchmarkGenerator_Eval3D64_smooth(b *testing.B) {
+	gen := opensimplex2.NewSmooth(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = gen.Eval3D64(float64(i)*0.01, 0.2, 0.3)
+	}
+}
@@ -0,0 +1,219 @@
+package perlin
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// EvalDeriv2D32 returns a float32 Perlin noise value at (x, y) and its partial
+// derivatives. It is a conversion of float64 to float32.
+//
+// See EvalDeriv2D64 for the details.
+func (n *Generator) EvalDeriv2D32(x, y float32) (v, dx, dy float32) {
+	v64, dx64, dy64 := n.EvalDeriv2D64(float64(x), float64(y))
+
+	return float32(v64), float32(dx64), float32(dy64)
+}
+
+// EvalDeriv2D64 returns a float64 Perlin noise value at (x, y) and its partial
+// derivatives along the x and y axes. The value is equal to the one of
+// Eval64(x, y) and the derivatives are computed analytically.
+func (n *Generator) EvalDeriv2D64(x, y float64) (v, dx, dy float64) {
+	c := n.state()
+
+	var grad [2]float64
+
+	scale := 1.
+	freq := 1.
+	px := [2]float64{x, y}
+
+	for i := int32(0); i < c.iteration; i++ {
+		val, d := c.tables.noise2Deriv(px)
+
+		v += val / scale
+
+		for j := range grad {
+			grad[j] += d[j] * freq / scale
+			px[j] *= c.scale
+		}
+
+		scale *= c.smoothness
+		freq *= c.scale
+	}
+
+	return v, grad[0], grad[1]
+}
+
+// EvalDeriv3D32 returns a float32 Perlin noise value at (x, y, z) and its
+// partial derivatives. It is a conversion of float64 to float32.
+//
+// See EvalDeriv3D64 for the details.
+func (n *Generator) EvalDeriv3D32(x, y, z float32) (v, dx, dy, dz float32) {
+	v64, dx64, dy64, dz64 := n.EvalDeriv3D64(float64(x), float64(y), float64(z))
+
+	return float32(v64), float32(dx64), float32(dy64), float32(dz64)
+}
+
+// EvalDeriv3D64 returns a float64 Perlin noise value at (x, y, z) and its
+// partial derivatives along the x, y and z axes. The value is equal to the one
+// of Eval64(x, y, z) and the derivatives are computed analytically.
+//
+// Note that go-perlin returns the 2D noise of (x, y) for the negative z. In that
+// case, the derivative along the z axis is 0.
+func (n *Generator) EvalDeriv3D64(x, y, z float64) (v, dx, dy, dz float64) {
+	if z < 0 {
+		v, dx, dy = n.EvalDeriv2D64(x, y)
+
+		return v, dx, dy, 0
+	}
+
+	c := n.state()
+
+	var grad [3]float64
+
+	scale := 1.
+	freq := 1.
+	px := [3]float64{x, y, z}
+
+	for i := int32(0); i < c.iteration; i++ {
+		val, d := c.tables.noise3Deriv(px)
+
+		v += val / scale
+
+		for j := range grad {
+			grad[j] += d[j] * freq / scale
+			px[j] *= c.scale
+		}
+
+		scale *= c.smoothness
+		freq *= c.scale
+	}
+
+	return v, grad[0], grad[1], grad[2]
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// noise2Deriv returns a single octave of 2-dimensional Perlin noise and its
+// gradient. The value is computed in the same order as go-perlin's noise2.
+func (t *tables) noise2Deriv(vec [2]float64) (float64, [2]float64) {
+	var (
+		b0, b1 [2]int32
+		r0, r1 [2]float64
+		s, ds  [2]float64
+	)
+
+	for i, v := range vec {
+		f := v + sizeN
+		b0[i] = int32(f) & maskBM
+		b1[i] = (b0[i] + 1) & maskBM
+		r0[i] = f - float64(int32(f))
+		r1[i] = r0[i] - 1.
+		s[i] = sCurve(r0[i])
+		ds[i] = sCurveDeriv(r0[i])
+	}
+
+	i := t.p[b0[0]]
+	j := t.p[b1[0]]
+
+	g00 := t.g2[t.p[i+b0[1]]]
+	g10 := t.g2[t.p[j+b0[1]]]
+	g01 := t.g2[t.p[i+b1[1]]]
+	g11 := t.g2[t.p[j+b1[1]]]
+
+	u0, v0 := at2(r0[0], r0[1], g00), at2(r1[0], r0[1], g10)
+	u1, v1 := at2(r0[0], r1[1], g01), at2(r1[0], r1[1], g11)
+
+	a := lerp(s[0], u0, v0)
+	b := lerp(s[0], u1, v1)
+
+	// The derivatives of a and b, the interpolations along the x axis.
+	var da, db [2]float64
+
+	for k := range da {
+		da[k] = lerp(s[0], g00[k], g10[k])
+		db[k] = lerp(s[0], g01[k], g11[k])
+	}
+
+	da[0] += ds[0] * (v0 - u0)
+	db[0] += ds[0] * (v1 - u1)
+
+	grad := [2]float64{
+		lerp(s[1], da[0], db[0]),
+		lerp(s[1], da[1], db[1]) + ds[1]*(b-a),
+	}
+
+	return lerp(s[1], a, b), grad
+}
+
+// noise3Deriv returns a single octave of 3-dimensional Perlin noise and its
+// gradient. The value is computed in the same order as go-perlin's noise3.
+func (t *tables) noise3Deriv(vec [3]float64) (float64, [3]float64) {
+	var (
+		b0, b1 [3]int32
+		r0, r1 [3]float64
+		s, ds  [3]float64
+	)
+
+	for i, v := range vec {
+		f := v + sizeN
+		b0[i] = int32(f) & maskBM
+		b1[i] = (b0[i] + 1) & maskBM
+		r0[i] = f - float64(int32(f))
+		r1[i] = r0[i] - 1.
+		s[i] = sCurve(r0[i])
+		ds[i] = sCurveDeriv(r0[i])
+	}
+
+	// The values and the gradients of the 8 corners of the cube. The bits of
+	// the corner index choose the lower or the upper lattice point of each axis.
+	var (
+		values [8]float64
+		grads  [8][3]float64
+	)
+
+	for c := range values {
+		var q [3]float64
+
+		b := int32(0)
+
+		for i := 0; i < 3; i++ {
+			bi, ri := b0[i], r0[i]
+			if c&(1<<i) != 0 {
+				bi, ri = b1[i], r1[i]
+			}
+
+			q[i] = ri
+
+			// go-perlin indexes the gradient by p[p[bx]+by]+bz.
+			if i < 2 {
+				b = t.p[b+bi]
+			} else {
+				b += bi
+			}
+		}
+
+		grads[c] = t.g3[b]
+		values[c] = at3(q[0], q[1], q[2], grads[c])
+	}
+
+	// Reduce the corners along x, y then z axis. The gradient of each
+	// interpolation is the interpolation of the gradients plus the derivative
+	// of the weight along the axis.
+	for i, size := 0, 8; i < 3; i, size = i+1, size/2 {
+		for c := 0; c < size/2; c++ {
+			lo, hi := values[2*c], values[2*c+1]
+			glo, ghi := grads[2*c], grads[2*c+1]
+
+			for k := range grads[c] {
+				grads[c][k] = lerp(s[i], glo[k], ghi[k])
+			}
+
+			grads[c][i] += ds[i] * (hi - lo)
+			values[c] = lerp(s[i], lo, hi)
+		}
+	}
+
+	return values[0], grads[0]
+}
@@ -94,15 +94,35 @@ func ExampleNew_eval32_three_dimmentions() {
 	// 1;1;1; 0.0009
 }
 
-func ExampleNew_eval32_more_than_three_dimmentions() {
+func ExampleNew_eval32_four_dimmentions() {
 	const (
-		seed = 100
+		seed       = 100
+		smoothness = 10
 	)
 
 	p := perlin.New(seed)
 
-	// It only supports up to three dimmentions.
-	fmt.Printf("%0.0f", p.Eval32(0.0001, 0.0001, 0.0001, 0.0001))
+	for w := float32(0); w < 3; w++ {
+		fmt.Printf(
+			"%0.0f; %0.4f\n",
+			w,
+			p.Eval32(0.1, 0.2, 0.3, w/smoothness),
+		)
+	}
+
+	// Output:
+	// 0; 0.3080
+	// 1; 0.3686
+	// 2; 0.3929
+}
+
+func ExampleNew_eval32_more_than_four_dimmentions() {
+	const seed = 100
+
+	p := perlin.New(seed)
+
+	// It only supports up to four dimmentions.
+	fmt.Printf("%0.0f", p.Eval32(0.1, 0.2, 0.3, 0.4, 0.5))
 
 	// Output: 0
 }
@@ -168,16 +188,35 @@ func ExampleNew_eval64_three_dimmentions() {
 	// 1;1;1;0.0304
 }
 
-func ExampleNew_eval64_more_than_three_dimmentions() {
+func ExampleNew_eval64_four_dimmentions() {
 	const (
 		seed       = 100
-		smoothness = 100
+		smoothness = 10
 	)
 
 	p := perlin.New(seed)
 
-	// It only supports up to three dimmentions.
-	fmt.Printf("%0.0f", p.Eval64(0.0001, 0.0001, 0.0001, 0.0001))
+	for w := float64(0); w < 3; w++ {
+		fmt.Printf(
+			"%0.0f; %0.4f\n",
+			w,
+			p.Eval64(0.1, 0.2, 0.3, w/smoothness),
+		)
+	}
+
+	// Output:
+	// 0; 0.3080
+	// 1; 0.3686
+	// 2; 0.3929
+}
+
+func ExampleNew_eval64_more_than_four_dimmentions() {
+	const seed = 100
+
+	p := perlin.New(seed)
+
+	// It only supports up to four dimmentions.
+	fmt.Printf("%0.0f", p.Eval64(0.1, 0.2, 0.3, 0.4, 0.5))
 
 	// Output: 0
 }
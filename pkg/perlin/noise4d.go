@@ -0,0 +1,165 @@
+package perlin
+
+import (
+	"math"
+	"math/rand"
+)
+
+// The lattice size and the offset constants are the same as go-perlin.
+const (
+	sizeB  = 0x100
+	sizeN  = 0x1000
+	maskBM = 0xff
+)
+
+// ----------------------------------------------------------------------------
+//  Type: perlin4D
+// ----------------------------------------------------------------------------
+
+// perlin4D holds the permutation and gradient tables of 4-dimensional Perlin
+// noise. Since go-perlin only supports up to 3 dimensions, it is implemented in
+// the same manner as go-perlin does for the lower dimensions.
+type perlin4D struct {
+	p     [sizeB + sizeB + 2]int32
+	g4    [sizeB + sizeB + 2][4]float64
+	alpha float64
+	beta  float64
+	n     int32
+}
+
+// newPerlin4D returns a seeded 4-dimensional Perlin noise instance. The alpha,
+// beta and n are the same as go-perlin's.
+func newPerlin4D(alpha, beta float64, n int32, seed int64) *perlin4D {
+	p := &perlin4D{
+		alpha: alpha,
+		beta:  beta,
+		n:     n,
+	}
+
+	//nolint:gosec // Use of weak random number generation is intended here.
+	r := rand.New(rand.NewSource(seed))
+
+	var i int32
+
+	for i = 0; i < sizeB; i++ {
+		p.p[i] = i
+
+		for j := 0; j < 4; j++ {
+			p.g4[i][j] = float64((r.Int31()%(sizeB+sizeB))-sizeB) / sizeB
+		}
+
+		normalize4(&p.g4[i])
+	}
+
+	// go-perlin starts the shuffle from sizeB, not sizeB-1.
+	for ; i > 0; i-- {
+		j := r.Int31() % sizeB
+		p.p[i], p.p[j] = p.p[j], p.p[i]
+	}
+
+	for i = 0; i < sizeB+2; i++ {
+		p.p[sizeB+i] = p.p[i]
+		p.g4[sizeB+i] = p.g4[i]
+	}
+
+	return p
+}
+
+// Noise4D returns the sum of the n octaves of 4-dimensional Perlin noise. The
+// octaves are weighted by alpha and scaled by beta as go-perlin does.
+func (p *perlin4D) Noise4D(x, y, z, w float64) float64 {
+	var sum float64
+
+	scale := 1.
+	px := [4]float64{x, y, z, w}
+
+	for i := int32(0); i < p.n; i++ {
+		sum += p.noise4(px) / scale
+		scale *= p.alpha
+
+		for j := range px {
+			px[j] *= p.beta
+		}
+	}
+
+	return sum
+}
+
+// noise4 returns a single octave of 4-dimensional Perlin noise.
+func (p *perlin4D) noise4(vec [4]float64) float64 {
+	var (
+		b0, b1 [4]int32
+		r0, r1 [4]float64
+		s      [4]float64
+	)
+
+	for i, v := range vec {
+		t := v + sizeN
+		b0[i] = int32(t) & maskBM
+		b1[i] = (b0[i] + 1) & maskBM
+		r0[i] = t - float64(int32(t))
+		r1[i] = r0[i] - 1.
+		s[i] = sCurve(r0[i])
+	}
+
+	// Interpolate the 16 corners of the hypercube. The bits of the corner index
+	// choose the lower or the upper lattice point of each axis.
+	var corners [16]float64
+
+	for c := range corners {
+		var (
+			b int32
+			q [4]float64
+		)
+
+		for i := 0; i < 4; i++ {
+			bi, ri := b0[i], r0[i]
+			if c&(1<<i) != 0 {
+				bi, ri = b1[i], r1[i]
+			}
+
+			q[i] = ri
+
+			// Same as go-perlin's g3[p[p[bx]+by]+bz], the last axis is added
+			// without the permutation.
+			if i < 3 {
+				b = p.p[b+bi]
+			} else {
+				b += bi
+			}
+		}
+
+		g := p.g4[b]
+		corners[c] = q[0]*g[0] + q[1]*g[1] + q[2]*g[2] + q[3]*g[3]
+	}
+
+	// Reduce the corners along x, y, z then w axis.
+	for i, size := 0, 16; i < 4; i, size = i+1, size/2 {
+		for c := 0; c < size/2; c++ {
+			corners[c] = lerp(s[i], corners[2*c], corners[2*c+1])
+		}
+	}
+
+	return corners[0]
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+func normalize4(v *[4]float64) {
+	s := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2] + v[3]*v[3])
+	if s == 0 {
+		return
+	}
+
+	v[0], v[1], v[2], v[3] = v[0]/s, v[1]/s, v[2]/s, v[3]/s
+}
+
+func sCurve(t float64) float64 {
+	return t * t * (3. - 2.*t)
+}
+
+func lerp(t, a, b float64) float64 {
+	return a + t*(b-a)
+}
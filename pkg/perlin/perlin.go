@@ -6,6 +6,9 @@ Which implements github.com/KEINOS/go-noise/noise interface.
 package perlin
 
 import (
+	"sync/atomic"
+
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/pkg/errors"
 
 	goperlin "github.com/aquilax/go-perlin"
@@ -20,6 +23,9 @@ const (
 	Iteration = int32(3)
 )
 
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
 // ----------------------------------------------------------------------------
 //  Constructor
 // ----------------------------------------------------------------------------
@@ -39,6 +45,9 @@ func New(seed int64) *Generator {
 // ----------------------------------------------------------------------------
 
 // Generator holds parameters of Perlin noise.
+//
+// It is safe to call the evaluation methods concurrently from multiple goroutines.
+// But the fields must not be changed while evaluating.
 type Generator struct {
 	// Smoothness is the weight when the sum is formed. Which is the alpha value
 	// in Ken Perlin's article. Default is 2. The smaller the number, the more
@@ -52,6 +61,22 @@ type Generator struct {
 	Iteration int32
 	// Seed holds the seed value for the noise.
 	Seed int64
+	// cache holds the *state, the permutation state built from the current
+	// parameters. It is built on the first evaluation and rebuilt only when any
+	// of the above parameters were changed.
+	cache atomic.Value
+}
+
+// state is a built go-perlin instance, the 4D Perlin instance, the replica of
+// the go-perlin tables and the parameters used to build them.
+type state struct {
+	perlin     *goperlin.Perlin
+	perlin4D   *perlin4D
+	tables     *tables
+	smoothness float64
+	scale      float64
+	seed       int64
+	iteration  int32
 }
 
 // ----------------------------------------------------------------------------
@@ -60,6 +85,8 @@ type Generator struct {
 
 // Eval32 returns a float32 Perlin noise value for the given coordinates.
 // It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
 func (n *Generator) Eval32(dim ...float32) float32 {
 	switch len(dim) {
 	case 1:
@@ -68,12 +95,16 @@ func (n *Generator) Eval32(dim ...float32) float32 {
 		return float32(n.eval2D(float64(dim[0]), float64(dim[1])))
 	case 3:
 		return float32(n.eval3D(float64(dim[0]), float64(dim[1]), float64(dim[2])))
+	case 4:
+		return float32(n.eval4D(float64(dim[0]), float64(dim[1]), float64(dim[2]), float64(dim[3])))
 	}
 
 	return 0
 }
 
 // Eval64 returns a float64 Perlin noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
 func (n *Generator) Eval64(dim ...float64) float64 {
 	switch len(dim) {
 	case 1:
@@ -82,11 +113,81 @@ func (n *Generator) Eval64(dim ...float64) float64 {
 		return n.eval2D(dim[0], dim[1])
 	case 3:
 		return n.eval3D(dim[0], dim[1], dim[2])
+	case 4:
+		return n.eval4D(dim[0], dim[1], dim[2], dim[3])
 	}
 
 	return 0
 }
 
+// Eval1D32 returns a float32 Perlin noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.eval1D(float64(x)))
+}
+
+// Eval2D32 returns a float32 Perlin noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.eval2D(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 Perlin noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.eval3D(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 Perlin noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.eval4D(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 Perlin noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return n.eval1D(x)
+}
+
+// Eval2D64 returns a float64 Perlin noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return n.eval2D(x, y)
+}
+
+// Eval3D64 returns a float64 Perlin noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return n.eval3D(x, y, z)
+}
+
+// Eval4D64 returns a float64 Perlin noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return n.eval4D(x, y, z, w)
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
 // SetEval32 is an implementation of noise.Generator interface. It will always
 // return an error.
 func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
@@ -99,27 +200,170 @@ func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error
 	return errors.New("float64 evaluation function is already set. You can not set custom function in Perlin type")
 }
 
+// EvalGrid2D32 fills dst with the float32 Perlin noise values at the sample
+// points of the 2D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval32(x, y) at the same sample point.
+func (n *Generator) EvalGrid2D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise()
+
+	for y := 0; y < g.Height; y++ {
+		yy := float64(float32(g.AtY(y)))
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = float32(p.Noise2D(float64(float32(g.AtX(x))), yy))
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid2D64 fills dst with the float64 Perlin noise values at the sample
+// points of the 2D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval64(x, y) at the same sample point.
+func (n *Generator) EvalGrid2D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate2D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise()
+
+	for y := 0; y < g.Height; y++ {
+		yy := g.AtY(y)
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = p.Noise2D(g.AtX(x), yy)
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D32 fills dst with the float32 Perlin noise values at the sample
+// points of the 3D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval32(x, y, z) at the same sample point.
+func (n *Generator) EvalGrid3D32(dst []float32, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise()
+
+	for z := 0; z < g.Depth; z++ {
+		zz := float64(float32(g.AtZ(z)))
+
+		for y := 0; y < g.Height; y++ {
+			yy := float64(float32(g.AtY(y)))
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = float32(p.Noise3D(float64(float32(g.AtX(x))), yy, zz))
+			}
+		}
+	}
+
+	return nil
+}
+
+// EvalGrid3D64 fills dst with the float64 Perlin noise values at the sample
+// points of the 3D grid. It returns an error if dst is too short for the grid.
+//
+// Each value is equal to the one of Eval64(x, y, z) at the same sample point.
+func (n *Generator) EvalGrid3D64(dst []float64, g *grid.Grid) error {
+	if err := g.Validate3D(len(dst)); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	p := n.noise()
+
+	for z := 0; z < g.Depth; z++ {
+		zz := g.AtZ(z)
+
+		for y := 0; y < g.Height; y++ {
+			yy := g.AtY(y)
+			row := dst[(z*g.Height+y)*g.Width : (z*g.Height+y+1)*g.Width]
+
+			for x := range row {
+				row[x] = p.Noise3D(g.AtX(x), yy, zz)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ----------------------------------------------------------------------------
 //  Methods (Private)
 // ----------------------------------------------------------------------------
 
 // eval1D generates float64 Perlin noise value from 1-dimensional coordinate.
 func (n *Generator) eval1D(x float64) float64 {
-	p := goperlin.NewPerlin(n.Smoothness, n.Scale, n.Iteration, n.Seed)
-
-	return p.Noise1D(x)
+	return n.noise().Noise1D(x)
 }
 
 // eval2D generates float64 Perlin noise value from 2-dimensional coordinates.
 func (n *Generator) eval2D(x, y float64) float64 {
-	p := goperlin.NewPerlin(n.Smoothness, n.Scale, n.Iteration, n.Seed)
-
-	return p.Noise2D(x, y)
+	return n.noise().Noise2D(x, y)
 }
 
 // eval3D generates float64 Perlin noise value from 3-dimensional coordinates.
 func (n *Generator) eval3D(x, y, z float64) float64 {
-	p := goperlin.NewPerlin(n.Smoothness, n.Scale, n.Iteration, n.Seed)
+	return n.noise().Noise3D(x, y, z)
+}
+
+// eval4D generates float64 Perlin noise value from 4-dimensional coordinates.
+func (n *Generator) eval4D(x, y, z, w float64) float64 {
+	return n.state().perlin4D.Noise4D(x, y, z, w)
+}
+
+// noise returns the cached go-perlin instance.
+func (n *Generator) noise() *goperlin.Perlin {
+	return n.state().perlin
+}
+
+// state returns the cached Perlin instances. It rebuilds them only if they have
+// not been built yet or the parameters of the generator were changed.
+func (n *Generator) state() *state {
+	if c, ok := n.cache.Load().(*state); ok &&
+		c.smoothness == n.Smoothness &&
+		c.scale == n.Scale &&
+		c.iteration == n.Iteration &&
+		c.seed == n.Seed {
+		return c
+	}
+
+	c := &state{
+		perlin:     goperlin.NewPerlin(n.Smoothness, n.Scale, n.Iteration, n.Seed),
+		perlin4D:   newPerlin4D(n.Smoothness, n.Scale, n.Iteration, n.Seed),
+		tables:     newTables(n.Seed),
+		smoothness: n.Smoothness,
+		scale:      n.Scale,
+		iteration:  n.Iteration,
+		seed:       n.Seed,
+	}
+
+	n.cache.Store(c)
+
+	return c
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Perlin supports 1 to %d dimensions", numDim, maxDim)
+	}
 
-	return p.Noise3D(x, y, z)
+	return nil
 }
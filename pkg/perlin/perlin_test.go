@@ -1,9 +1,15 @@
 package perlin_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
 	"testing"
 
+	"github.com/KEINOS/go-noise/pkg/grid"
 	"github.com/KEINOS/go-noise/pkg/perlin"
 	goperlin "github.com/aquilax/go-perlin"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +37,95 @@ func Test_original_perlin(t *testing.T) {
 	}
 }
 
+// TestSamplesMatch is a regression test of the 1D to 4D noise values of seed 0.
+func TestSamplesMatch(t *testing.T) {
+	samples := loadSamples(t)
+
+	p := perlin.New(0)
+
+	for _, s := range samples {
+		coord, expected := s[:len(s)-1], s[len(s)-1]
+
+		require.Equal(t, expected, p.Eval64(coord...), "%dD sample at %v", len(coord), coord)
+	}
+}
+
+// TestSamplesMatch_reference checks that the golden values are the ones of the
+// independent implementations. The 1D to 3D values are of go-perlin and the 4D
+// values are of referencePerlin4D.
+func TestSamplesMatch_reference(t *testing.T) {
+	const seed = 0
+
+	gp := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, seed)
+	ref := newReferencePerlin4D(seed)
+
+	for _, s := range loadSamples(t) {
+		coord, expected := s[:len(s)-1], s[len(s)-1]
+
+		var actual float64
+
+		switch len(coord) {
+		case 1:
+			actual = gp.Noise1D(coord[0])
+		case 2:
+			actual = gp.Noise2D(coord[0], coord[1])
+		case 3:
+			actual = gp.Noise3D(coord[0], coord[1], coord[2])
+		case 4:
+			actual = ref.noise4D(coord[0], coord[1], coord[2], coord[3])
+		}
+
+		require.Equal(t, expected, actual, "%dD sample at %v", len(coord), coord)
+	}
+}
+
+func TestGenerator_eval_4D_equals_to_reference(t *testing.T) {
+	for _, seed := range []int64{0, 100, -12345} {
+		p := perlin.New(seed)
+		ref := newReferencePerlin4D(seed)
+
+		for i := 0.; i < 200; i++ {
+			x, y, z, w := i*0.173-11.3, i*-0.219+7.1, i*0.051, i*0.317-30.9
+
+			require.Equal(t, ref.noise4D(x, y, z, w), p.Eval64(x, y, z, w),
+				"seed: %d, coordinates: %v, %v, %v, %v", seed, x, y, z, w)
+		}
+	}
+}
+
+func TestGenerator_eval_4D(t *testing.T) {
+	p := perlin.New(100)
+
+	v64 := p.Eval64(0.1, 0.2, 0.3, 0.4)
+	v32 := p.Eval32(0.1, 0.2, 0.3, 0.4)
+
+	require.NotZero(t, v64, "4D noise should be supported")
+	require.Equal(t, p.Eval64(0.1, 0.2, 0.3, 0.4), v64, "same coordinates should return the same value")
+	require.InDelta(t, v64, float64(v32), 1e-6, "float32 value should be the conversion of float64")
+	require.NotEqual(t, v64, p.Eval64(0.1, 0.2, 0.3, 0.5), "w-axis should affect the value")
+
+	// At the lattice points the noise is zero as well as lower dimensions.
+	require.Zero(t, p.Eval64(0, 0, 0, 0))
+}
+
+func TestGenerator_eval_4D_is_in_range(t *testing.T) {
+	p := perlin.New(100)
+
+	minV, maxV := math.Inf(1), math.Inf(-1)
+
+	for i := 0; i < 10000; i++ {
+		f := float64(i)
+		v := p.Eval64(f*0.037, f*0.051, f*0.013, f*0.071)
+
+		minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+	}
+
+	require.GreaterOrEqual(t, minV, -1., "it should be in the range of -1 to 1")
+	require.LessOrEqual(t, maxV, 1., "it should be in the range of -1 to 1")
+	require.Less(t, minV, -0.1, "it should be spread in the range")
+	require.Greater(t, maxV, 0.1, "it should be spread in the range")
+}
+
 func TestNew_instantiate(t *testing.T) {
 	const seed = 100
 
@@ -41,3 +136,557 @@ func TestNew_instantiate(t *testing.T) {
 	require.Equal(t, int64(seed), p.Seed, "the Seed should be int64(seed) by default")
 	require.Equal(t, perlin.Iteration, p.Iteration, "the Iteration should be perlin.Iteration by default")
 }
+
+func TestGenerator_rebuild_on_parameter_change(t *testing.T) {
+	const (
+		seed = 100
+		x    = 0.1
+		y    = 0.2
+	)
+
+	p := perlin.New(seed)
+
+	for _, test := range []struct {
+		update func(g *perlin.Generator)
+		name   string
+	}{
+		{name: "Seed", update: func(g *perlin.Generator) { g.Seed = seed + 1 }},
+		{name: "Smoothness", update: func(g *perlin.Generator) { g.Smoothness = 1.5 }},
+		{name: "Scale", update: func(g *perlin.Generator) { g.Scale = 1.5 }},
+		{name: "Iteration", update: func(g *perlin.Generator) { g.Iteration = 5 }},
+	} {
+		before := p.Eval64(x, y)
+
+		test.update(p)
+
+		expect := goperlin.NewPerlin(p.Smoothness, p.Scale, p.Iteration, p.Seed).Noise2D(x, y)
+		actual := p.Eval64(x, y)
+
+		require.Equal(t, expect, actual, "changing %s should rebuild the permutation state", test.name)
+		require.NotEqual(t, before, actual, "changing %s should change the noise value", test.name)
+	}
+}
+
+func TestGenerator_EvalGrid_equals_to_eval(t *testing.T) {
+	p := perlin.New(100)
+	g := &grid.Grid{
+		X: -0.5, Y: 0.25, Z: 1,
+		StepX: 0.1, StepY: 0.07, StepZ: 0.2,
+		Width: 5, Height: 4, Depth: 3,
+	}
+
+	out2D32 := make([]float32, g.Len2D())
+	out2D64 := make([]float64, g.Len2D())
+
+	require.NoError(t, p.EvalGrid2D32(out2D32, g))
+	require.NoError(t, p.EvalGrid2D64(out2D64, g))
+
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			i := y*g.Width + x
+
+			require.Equal(t, p.Eval32(float32(g.AtX(x)), float32(g.AtY(y))), out2D32[i])
+			require.Equal(t, p.Eval64(g.AtX(x), g.AtY(y)), out2D64[i])
+		}
+	}
+
+	out3D32 := make([]float32, g.Len3D())
+	out3D64 := make([]float64, g.Len3D())
+
+	require.NoError(t, p.EvalGrid3D32(out3D32, g))
+	require.NoError(t, p.EvalGrid3D64(out3D64, g))
+
+	for z := 0; z < g.Depth; z++ {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				i := (z*g.Height+y)*g.Width + x
+
+				require.Equal(t, p.Eval32(float32(g.AtX(x)), float32(g.AtY(y)), float32(g.AtZ(z))), out3D32[i])
+				require.Equal(t, p.Eval64(g.AtX(x), g.AtY(y), g.AtZ(z)), out3D64[i])
+			}
+		}
+	}
+}
+
+func TestGenerator_EvalGrid_short_destination(t *testing.T) {
+	p := perlin.New(100)
+	g := &grid.Grid{Width: 5, Height: 4, Depth: 3}
+
+	require.Error(t, p.EvalGrid2D32(make([]float32, 1), g))
+	require.Error(t, p.EvalGrid2D64(make([]float64, 1), g))
+	require.Error(t, p.EvalGrid3D32(make([]float32, g.Len2D()), g))
+	require.Error(t, p.EvalGrid3D64(make([]float64, g.Len2D()), g))
+}
+
+func TestGenerator_EvalTile_equals_to_eval_inside(t *testing.T) {
+	const width, height = 8., 4.
+
+	for _, seed := range []int64{0, 100, -5} {
+		p := perlin.New(seed)
+
+		// The lattice is not wrapped before the last cell of the tile.
+		for y := 0.; y < height-1; y += 0.125 {
+			for x := 0.; x < width-1; x += 0.125 {
+				require.Equal(t, p.Eval64(x, y), p.EvalTile2D64(x, y, width, height),
+					"seed %d at (%v, %v)", seed, x, y)
+			}
+		}
+	}
+}
+
+func TestGenerator_EvalTile_edges_match(t *testing.T) {
+	const width, height = 5., 3.
+
+	p := perlin.New(100)
+
+	for i := 0.; i <= 40; i++ {
+		x, y := i*width/40, i*height/40
+
+		// The edges of the tile must match exactly to be seamless.
+		require.Equal(t, p.EvalTile2D64(0, y, width, height), p.EvalTile2D64(width, y, width, height))
+		require.Equal(t, p.EvalTile2D64(x, 0, width, height), p.EvalTile2D64(x, height, width, height))
+
+		// Outside of the tile, it repeats.
+		require.Equal(t, p.EvalTile2D64(x, y, width, height), p.EvalTile2D64(x-width, y+2*height, width, height))
+	}
+
+	// The last cell of the tile is continuous to the first one.
+	last := p.EvalTile2D64(width-1e-9, 1.5, width, height)
+	first := p.EvalTile2D64(0, 1.5, width, height)
+
+	require.InDelta(t, first, last, 1e-6)
+	require.NotEqual(t, p.Eval64(width-1e-9, 1.5), last, "the lattice should be wrapped")
+}
+
+func TestGenerator_EvalTile_non_integer_period(t *testing.T) {
+	const width, height = 2.56, 1.6
+
+	p := perlin.New(100)
+	p.Scale = 2.5
+
+	for y := 0.; y < height; y += 0.1 {
+		// The octaves are stretched to fit the lattice, so the seams of all the
+		// octaves are continuous.
+		require.InDelta(t, p.EvalTile2D64(0, y, width, height), p.EvalTile2D64(width-1e-9, y, width, height), 1e-6,
+			"the seam along the x axis should be continuous at y=%v", y)
+		require.InDelta(t, p.EvalTile2D64(y, 0, width, height), p.EvalTile2D64(y, height-1e-9, width, height), 1e-6,
+			"the seam along the y axis should be continuous at x=%v", y)
+	}
+}
+
+func TestGenerator_EvalTile_non_positive_period(t *testing.T) {
+	p := perlin.New(100)
+
+	require.Zero(t, p.EvalTile2D64(0.5, 0.5, 0, 1))
+	require.Zero(t, p.EvalTile2D64(0.5, 0.5, 1, -1))
+	require.Zero(t, p.EvalTile2D32(0.5, 0.5, 0, 1))
+}
+
+func TestGenerator_EvalTile2D32(t *testing.T) {
+	p := perlin.New(100)
+
+	v64 := p.EvalTile2D64(1.25, 2.5, 4, 4)
+	v32 := p.EvalTile2D32(1.25, 2.5, 4, 4)
+
+	require.NotZero(t, v64)
+	require.InDelta(t, v64, float64(v32), 1e-6, "float32 value should be the conversion of float64")
+}
+
+func TestGenerator_EvalDeriv_equals_to_numeric(t *testing.T) {
+	const h = 1e-6
+
+	for _, seed := range []int64{0, 100, -5} {
+		p := perlin.New(seed)
+
+		for i := 0; i < 100; i++ {
+			x, y, z := float64(i)*0.173-3.1, float64(i)*0.0917+0.05, float64(i)*0.061+0.02
+
+			v, dx, dy := p.EvalDeriv2D64(x, y)
+
+			require.Equal(t, p.Eval64(x, y), v, "the value should be the same as Eval64")
+			require.InDelta(t, (p.Eval64(x+h, y)-p.Eval64(x-h, y))/(2*h), dx, 1e-6, "dx at (%v, %v)", x, y)
+			require.InDelta(t, (p.Eval64(x, y+h)-p.Eval64(x, y-h))/(2*h), dy, 1e-6, "dy at (%v, %v)", x, y)
+
+			v, dx, dy, dz := p.EvalDeriv3D64(x, y, z)
+
+			require.Equal(t, p.Eval64(x, y, z), v, "the value should be the same as Eval64")
+			require.InDelta(t, (p.Eval64(x+h, y, z)-p.Eval64(x-h, y, z))/(2*h), dx, 1e-6, "dx at (%v, %v, %v)", x, y, z)
+			require.InDelta(t, (p.Eval64(x, y+h, z)-p.Eval64(x, y-h, z))/(2*h), dy, 1e-6, "dy at (%v, %v, %v)", x, y, z)
+			require.InDelta(t, (p.Eval64(x, y, z+h)-p.Eval64(x, y, z-h))/(2*h), dz, 1e-6, "dz at (%v, %v, %v)", x, y, z)
+		}
+	}
+}
+
+func TestGenerator_EvalDeriv3D_negative_z(t *testing.T) {
+	p := perlin.New(100)
+
+	// go-perlin returns the 2D noise for the negative z.
+	v2, dx2, dy2 := p.EvalDeriv2D64(0.3, 0.7)
+	v3, dx3, dy3, dz3 := p.EvalDeriv3D64(0.3, 0.7, -0.5)
+
+	require.Equal(t, p.Eval64(0.3, 0.7, -0.5), v3)
+	require.Equal(t, []float64{v2, dx2, dy2, 0}, []float64{v3, dx3, dy3, dz3})
+}
+
+func TestGenerator_EvalDeriv32(t *testing.T) {
+	p := perlin.New(100)
+
+	v64, dx64, dy64 := p.EvalDeriv2D64(0.3, 0.7)
+	v32, dx32, dy32 := p.EvalDeriv2D32(0.3, 0.7)
+
+	require.InDelta(t, v64, float64(v32), 1e-6)
+	require.InDelta(t, dx64, float64(dx32), 1e-6)
+	require.InDelta(t, dy64, float64(dy32), 1e-6)
+
+	v64, dx64, dy64, dz64 := p.EvalDeriv3D64(0.3, 0.7, 0.2)
+	v32, dx32, dy32, dz32 := p.EvalDeriv3D32(0.3, 0.7, 0.2)
+
+	require.InDelta(t, v64, float64(v32), 1e-6)
+	require.InDelta(t, dx64, float64(dx32), 1e-6)
+	require.InDelta(t, dy64, float64(dy32), 1e-6)
+	require.InDelta(t, dz64, float64(dz32), 1e-6)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	p := perlin.New(100)
+
+	for _, dim := range [][]float64{
+		{0.1},
+		{0.1, 0.2},
+		{0.1, 0.2, 0.3},
+		{0.1, 0.2, 0.3, 0.4},
+	} {
+		dim32 := make([]float32, len(dim))
+		for i, v := range dim {
+			dim32[i] = float32(v)
+		}
+
+		v64, err := p.Eval64E(dim...)
+
+		require.NoError(t, err, "%dD should be supported", len(dim))
+		require.Equal(t, p.Eval64(dim...), v64)
+
+		v32, err := p.Eval32E(dim32...)
+
+		require.NoError(t, err, "%dD should be supported", len(dim))
+		require.Equal(t, p.Eval32(dim32...), v32)
+	}
+
+	for _, dim := range [][]float64{
+		{},
+		{0.1, 0.2, 0.3, 0.4, 0.5},
+	} {
+		_, err := p.Eval64E(dim...)
+
+		require.Error(t, err, "%dD should not be supported", len(dim))
+		require.Contains(t, err.Error(), "unsupported number of dimensions")
+
+		_, err = p.Eval32E(make([]float32, len(dim))...)
+
+		require.Error(t, err, "%dD should not be supported", len(dim))
+		require.Contains(t, err.Error(), "unsupported number of dimensions")
+	}
+}
+
+func TestGenerator_fixed_arity_equals_to_variadic(t *testing.T) {
+	p := perlin.New(100)
+
+	require.Equal(t, p.Eval32(0.1), p.Eval1D32(0.1))
+	require.Equal(t, p.Eval32(0.1, 0.2), p.Eval2D32(0.1, 0.2))
+	require.Equal(t, p.Eval32(0.1, 0.2, 0.3), p.Eval3D32(0.1, 0.2, 0.3))
+	require.Equal(t, p.Eval32(0.1, 0.2, 0.3, 0.4), p.Eval4D32(0.1, 0.2, 0.3, 0.4))
+	require.Equal(t, p.Eval64(0.1), p.Eval1D64(0.1))
+	require.Equal(t, p.Eval64(0.1, 0.2), p.Eval2D64(0.1, 0.2))
+	require.Equal(t, p.Eval64(0.1, 0.2, 0.3), p.Eval3D64(0.1, 0.2, 0.3))
+	require.Equal(t, p.Eval64(0.1, 0.2, 0.3, 0.4), p.Eval4D64(0.1, 0.2, 0.3, 0.4))
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	p := perlin.New(100)
+
+	// Build the cached state before measuring.
+	_ = p.Eval1D64(0)
+
+	for name, eval := range map[string]func(){
+		"Eval1D32": func() { _ = p.Eval1D32(0.1) },
+		"Eval2D32": func() { _ = p.Eval2D32(0.1, 0.2) },
+		"Eval3D32": func() { _ = p.Eval3D32(0.1, 0.2, 0.3) },
+		"Eval4D32": func() { _ = p.Eval4D32(0.1, 0.2, 0.3, 0.4) },
+		"Eval1D64": func() { _ = p.Eval1D64(0.1) },
+		"Eval2D64": func() { _ = p.Eval2D64(0.1, 0.2) },
+		"Eval3D64": func() { _ = p.Eval3D64(0.1, 0.2, 0.3) },
+		"Eval4D64": func() { _ = p.Eval4D64(0.1, 0.2, 0.3, 0.4) },
+	} {
+		require.Zero(t, testing.AllocsPerRun(100, eval), "%s should not allocate", name)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Benchmarks
+// ----------------------------------------------------------------------------
+//  The "Rebuild" benchmarks re-create the go-perlin instance on every call to
+//  compare with the cached state of the Generator.
+
+func BenchmarkGenerator_Eval32_1D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval32(float32(i) / 100)
+	}
+}
+
+func BenchmarkGenerator_Eval32_2D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval32(float32(i)/100, float32(i)/200)
+	}
+}
+
+func BenchmarkGenerator_Eval32_3D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval32(float32(i)/100, float32(i)/200, float32(i)/300)
+	}
+}
+
+func BenchmarkGenerator_Eval64_1D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval64(float64(i) / 100)
+	}
+}
+
+func BenchmarkGenerator_Eval64_2D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval64(float64(i)/100, float64(i)/200)
+	}
+}
+
+func BenchmarkGenerator_Eval64_3D(b *testing.B) {
+	p := perlin.New(100)
+
+	for i := 0; i < b.N; i++ {
+		_ = p.Eval64(float64(i)/100, float64(i)/200, float64(i)/300)
+	}
+}
+
+func BenchmarkRebuild_Eval32_1D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = float32(p.Noise1D(float64(float32(i) / 100)))
+	}
+}
+
+func BenchmarkRebuild_Eval32_2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = float32(p.Noise2D(float64(float32(i)/100), float64(float32(i)/200)))
+	}
+}
+
+func BenchmarkRebuild_Eval32_3D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = float32(p.Noise3D(float64(float32(i)/100), float64(float32(i)/200), float64(float32(i)/300)))
+	}
+}
+
+func BenchmarkRebuild_Eval64_1D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = p.Noise1D(float64(i) / 100)
+	}
+}
+
+func BenchmarkRebuild_Eval64_2D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = p.Noise2D(float64(i)/100, float64(i)/200)
+	}
+}
+
+func BenchmarkRebuild_Eval64_3D(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		p := goperlin.NewPerlin(perlin.Alpha, perlin.Beta, perlin.Iteration, 100)
+
+		_ = p.Noise3D(float64(i)/100, float64(i)/200, float64(i)/300)
+	}
+}
+
+func BenchmarkGenerator_EvalGrid2D64(b *testing.B) {
+	p := perlin.New(100)
+	g := &grid.Grid{StepX: 0.01, StepY: 0.01, Width: 100, Height: 100}
+	dst := make([]float64, g.Len2D())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = p.EvalGrid2D64(dst, g)
+	}
+}
+
+func BenchmarkGenerator_Eval64_grid2D(b *testing.B) {
+	p := perlin.New(100)
+	g := &grid.Grid{StepX: 0.01, StepY: 0.01, Width: 100, Height: 100}
+	dst := make([]float64, g.Len2D())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				dst[y*g.Width+x] = p.Eval64(g.AtX(x), g.AtY(y))
+			}
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Helper Functions
+// ----------------------------------------------------------------------------
+
+// loadSamples returns the golden values of "testdata/perlin.json". Each sample
+// is the coordinates followed by the expected noise value.
+func loadSamples(t *testing.T) [][]float64 {
+	t.Helper()
+
+	f, err := os.Open("../../testdata/perlin.json")
+	require.NoError(t, err)
+
+	defer f.Close()
+
+	var samples [][]float64
+
+	dec := json.NewDecoder(f)
+
+	for {
+		var sample []float64
+
+		err := dec.Decode(&sample)
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+
+		samples = append(samples, sample)
+	}
+
+	return samples
+}
+
+// referencePerlin4D is a straightforward implementation of 4-dimensional Perlin
+// noise to check the optimized one of the package. It extends go-perlin's
+// NewPerlin and noise3 by the w axis as is, without the loops over the corners.
+type referencePerlin4D struct {
+	p  [0x100 + 0x100 + 2]int32
+	g4 [0x100 + 0x100 + 2][4]float64
+}
+
+func newReferencePerlin4D(seed int64) *referencePerlin4D {
+	const b = 0x100
+
+	var (
+		ref  referencePerlin4D
+		i, j int32
+	)
+
+	//nolint:gosec // Use of weak random number generation is intended here.
+	r := rand.New(rand.NewSource(seed))
+
+	for i = 0; i < b; i++ {
+		ref.p[i] = i
+
+		for j = 0; j < 4; j++ {
+			ref.g4[i][j] = float64((r.Int31()%(b+b))-b) / b
+		}
+
+		g := &ref.g4[i]
+		s := math.Sqrt(g[0]*g[0] + g[1]*g[1] + g[2]*g[2] + g[3]*g[3])
+		g[0], g[1], g[2], g[3] = g[0]/s, g[1]/s, g[2]/s, g[3]/s
+	}
+
+	for ; i > 0; i-- {
+		j = r.Int31() % b
+		ref.p[i], ref.p[j] = ref.p[j], ref.p[i]
+	}
+
+	for i = 0; i < b+2; i++ {
+		ref.p[b+i] = ref.p[i]
+		ref.g4[b+i] = ref.g4[i]
+	}
+
+	return &ref
+}
+
+// noise4D returns the sum of the octaves as go-perlin's Noise3D does.
+func (ref *referencePerlin4D) noise4D(x, y, z, w float64) float64 {
+	var sum float64
+
+	scale := 1.
+
+	for i := int32(0); i < perlin.Iteration; i++ {
+		sum += ref.noise4(x, y, z, w) / scale
+		scale *= perlin.Alpha
+		x, y, z, w = x*perlin.Beta, y*perlin.Beta, z*perlin.Beta, w*perlin.Beta
+	}
+
+	return sum
+}
+
+func (ref *referencePerlin4D) noise4(x, y, z, w float64) float64 {
+	const (
+		n  = 0x1000
+		bm = 0xff
+	)
+
+	setup := func(v float64) (b0, b1 int32, r0, r1 float64) {
+		t := v + n
+		b0 = int32(t) & bm
+		b1 = (b0 + 1) & bm
+		r0 = t - float64(int32(t))
+
+		return b0, b1, r0, r0 - 1.
+	}
+
+	sCurve := func(t float64) float64 { return t * t * (3. - 2.*t) }
+	lerp := func(t, a, b float64) float64 { return a + t*(b-a) }
+
+	bx0, bx1, rx0, rx1 := setup(x)
+	by0, by1, ry0, ry1 := setup(y)
+	bz0, bz1, rz0, rz1 := setup(z)
+	bw0, bw1, rw0, rw1 := setup(w)
+
+	at := func(bx, by, bz, bw int32, rx, ry, rz, rw float64) float64 {
+		q := ref.g4[ref.p[ref.p[ref.p[bx]+by]+bz]+bw]
+
+		return rx*q[0] + ry*q[1] + rz*q[2] + rw*q[3]
+	}
+
+	sx, sy, sz, sw := sCurve(rx0), sCurve(ry0), sCurve(rz0), sCurve(rw0)
+
+	cube := func(bw int32, rw float64) float64 {
+		c := lerp(sy,
+			lerp(sx, at(bx0, by0, bz0, bw, rx0, ry0, rz0, rw), at(bx1, by0, bz0, bw, rx1, ry0, rz0, rw)),
+			lerp(sx, at(bx0, by1, bz0, bw, rx0, ry1, rz0, rw), at(bx1, by1, bz0, bw, rx1, ry1, rz0, rw)),
+		)
+		d := lerp(sy,
+			lerp(sx, at(bx0, by0, bz1, bw, rx0, ry0, rz1, rw), at(bx1, by0, bz1, bw, rx1, ry0, rz1, rw)),
+			lerp(sx, at(bx0, by1, bz1, bw, rx0, ry1, rz1, rw), at(bx1, by1, bz1, bw, rx1, ry1, rz1, rw)),
+		)
+
+		return lerp(sz, c, d)
+	}
+
+	return lerp(sw, cube(bw0, rw0), cube(bw1, rw1))
+}
@@ -0,0 +1,89 @@
+package perlin
+
+import (
+	"math"
+	"math/rand"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: tables
+// ----------------------------------------------------------------------------
+
+// tables holds the permutation and gradient tables of go-perlin. Since they are
+// not exported, they are rebuilt in the same manner as go-perlin does. So the
+// noise values using them are the same as go-perlin's for the same seed.
+type tables struct {
+	p  [sizeB + sizeB + 2]int32
+	g1 [sizeB + sizeB + 2]float64
+	g2 [sizeB + sizeB + 2][2]float64
+	g3 [sizeB + sizeB + 2][3]float64
+}
+
+// newTables returns the seeded tables of go-perlin.
+func newTables(seed int64) *tables {
+	t := new(tables)
+
+	//nolint:gosec // Use of weak random number generation is intended here.
+	r := rand.New(rand.NewSource(seed))
+
+	var i int32
+
+	for i = 0; i < sizeB; i++ {
+		t.p[i] = i
+		t.g1[i] = float64((r.Int31()%(sizeB+sizeB))-sizeB) / sizeB
+
+		for j := 0; j < 2; j++ {
+			t.g2[i][j] = float64((r.Int31()%(sizeB+sizeB))-sizeB) / sizeB
+		}
+
+		normalize2(&t.g2[i])
+
+		for j := 0; j < 3; j++ {
+			t.g3[i][j] = float64((r.Int31()%(sizeB+sizeB))-sizeB) / sizeB
+		}
+
+		normalize3(&t.g3[i])
+	}
+
+	// go-perlin starts the shuffle from sizeB, not sizeB-1.
+	for ; i > 0; i-- {
+		j := r.Int31() % sizeB
+		t.p[i], t.p[j] = t.p[j], t.p[i]
+	}
+
+	for i = 0; i < sizeB+2; i++ {
+		t.p[sizeB+i] = t.p[i]
+		t.g1[sizeB+i] = t.g1[i]
+		t.g2[sizeB+i] = t.g2[i]
+		t.g3[sizeB+i] = t.g3[i]
+	}
+
+	return t
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+func normalize2(v *[2]float64) {
+	s := math.Sqrt(v[0]*v[0] + v[1]*v[1])
+	v[0], v[1] = v[0]/s, v[1]/s
+}
+
+func normalize3(v *[3]float64) {
+	s := math.Sqrt(v[0]*v[0] + v[1]*v[1] + v[2]*v[2])
+	v[0], v[1], v[2] = v[0]/s, v[1]/s, v[2]/s
+}
+
+func at2(rx, ry float64, q [2]float64) float64 {
+	return rx*q[0] + ry*q[1]
+}
+
+func at3(rx, ry, rz float64, q [3]float64) float64 {
+	return rx*q[0] + ry*q[1] + rz*q[2]
+}
+
+// sCurveDeriv returns the derivative of sCurve at t.
+func sCurveDeriv(t float64) float64 {
+	return 6 * t * (1 - t)
+}
@@ -0,0 +1,139 @@
+package perlin
+
+import "math"
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// EvalTile2D32 returns a float32 Perlin noise value at (x, y) which repeats every
+// width and height. It is a conversion of float64 to float32.
+//
+// See EvalTile2D64 for the details.
+func (n *Generator) EvalTile2D32(x, y, width, height float32) float32 {
+	return float32(n.EvalTile2D64(float64(x), float64(y), float64(width), float64(height)))
+}
+
+// EvalTile2D64 returns a float64 Perlin noise value at (x, y) which repeats every
+// width and height. It returns 0 if width or height is not positive.
+//
+// It wraps the lattice of each octave at the period of width and height scaled
+// by the Scale of the octave, rounded to the nearest integer. If the period is
+// not an integer, the octave is stretched slightly to fit the period to the
+// rounded one, so that it tiles seamlessly anyway. If the width, the height and
+// the Scale are integers, the value is equal to the one of Eval64(x, y) for
+// 0 <= x < width-1 and 0 <= y < height-1.
+func (n *Generator) EvalTile2D64(x, y, width, height float64) float64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+
+	c := n.state()
+
+	var sum float64
+
+	scale := 1.
+	px := [2]float64{wrap(x, width), wrap(y, height)}
+	period := [2]float64{width, height}
+
+	for i := int32(0); i < c.iteration; i++ {
+		var (
+			l  [2]int32
+			pl [2]float64
+		)
+
+		for j := range px {
+			l[j] = lattice(period[j])
+			// The ratio is exactly 1 if the period is an integer.
+			pl[j] = px[j] * (float64(l[j]) / period[j])
+		}
+
+		sum += c.tables.noise2Tile(pl, l) / scale
+		scale *= c.smoothness
+
+		for j := range px {
+			px[j] *= c.scale
+			period[j] *= c.scale
+		}
+	}
+
+	return sum
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// noise2Tile returns a single octave of 2-dimensional Perlin noise whose lattice
+// repeats every period. It is the same as go-perlin's noise2 except the
+// wrapping of the lattice.
+func (t *tables) noise2Tile(vec [2]float64, period [2]int32) float64 {
+	var (
+		b0, b1 [2]int32
+		r0, r1 [2]float64
+	)
+
+	for i, v := range vec {
+		f := v + sizeN
+		c := int32(f) - sizeN
+		b0[i] = mod(c, period[i]) & maskBM
+		b1[i] = mod(c+1, period[i]) & maskBM
+		r0[i] = f - float64(int32(f))
+		r1[i] = r0[i] - 1.
+	}
+
+	i := t.p[b0[0]]
+	j := t.p[b1[0]]
+
+	b00 := t.p[i+b0[1]]
+	b10 := t.p[j+b0[1]]
+	b01 := t.p[i+b1[1]]
+	b11 := t.p[j+b1[1]]
+
+	sx := sCurve(r0[0])
+	sy := sCurve(r0[1])
+
+	a := lerp(sx, at2(r0[0], r0[1], t.g2[b00]), at2(r1[0], r0[1], t.g2[b10]))
+	b := lerp(sx, at2(r0[0], r1[1], t.g2[b01]), at2(r1[0], r1[1], t.g2[b11]))
+
+	return lerp(sy, a, b)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// lattice returns the period of the lattice, the period rounded to the nearest
+// integer of at least 1.
+func lattice(period float64) int32 {
+	if l := int32(math.Round(period)); l > 1 {
+		return l
+	}
+
+	return 1
+}
+
+// mod returns the non-negative remainder of a divided by b.
+func mod(a, b int32) int32 {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+
+	return m
+}
+
+// wrap returns v wrapped into the range of [0, period).
+func wrap(v, period float64) float64 {
+	v = math.Mod(v, period)
+	if v < 0 {
+		v += period
+	}
+
+	if v >= period {
+		// v was a tiny negative value and rounded up to the period.
+		return 0
+	}
+
+	return v
+}
@@ -0,0 +1,30 @@
+package simplex_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/simplex"
+)
+
+func ExampleNew() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := simplex.New(seed)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f\n",
+			gen.Eval64(x/smoothness),
+			gen.Eval64(x/smoothness, 0.3),
+			gen.Eval64(x/smoothness, 0.3, 0.6),
+			gen.Eval64(x/smoothness, 0.3, 0.6, 0.9),
+		)
+	}
+
+	// Output:
+	// -0.3040;0.4081;0.1250;-0.1888
+	// -0.5421;0.2648;0.4058;-0.2079
+	// -0.6688;-0.1004;0.6485;-0.1363
+}
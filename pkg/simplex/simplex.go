@@ -0,0 +1,485 @@
+/*
+Package simplex is a native implementation of Ken Perlin's classic Simplex noise
+which implements github.com/KEINOS/go-noise/noise interface.
+
+It is based on Stefan Gustavson's public domain implementation and supports 1
+to 4 dimensions. The noise values are in the range of [-1, 1].
+*/
+package simplex
+
+import (
+	"math"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// Skewing and unskewing factors of each dimension.
+const (
+	f2 = 0.36602540378443864676 // (sqrt(3) - 1) / 2
+	g2 = 0.21132486540518711775 // (3 - sqrt(3)) / 6
+	f3 = 1. / 3.
+	g3 = 1. / 6.
+	f4 = 0.30901699437494742410 // (sqrt(5) - 1) / 4
+	g4 = 0.13819660112501051518 // (5 - sqrt(5)) / 20
+)
+
+// Scaling factors of each dimension to fit the sum of the contributions in the
+// range of [-1, 1].
+const (
+	scale1D = 0.395
+	scale2D = 40.
+	scale3D = 32.
+	scale4D = 27.
+)
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a seeded Simplex noise instance.
+func New(seed int64) *Generator {
+	return &Generator{
+		Seed: seed,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for Simplex noise. It is an implementation of
+// Generator interface.
+//
+// It is safe to call the evaluation methods concurrently from multiple goroutines.
+// But the Seed must not be changed while evaluating.
+type Generator struct {
+	// cache holds the *state, the permutation table built from the current seed.
+	// It is built on the first evaluation and rebuilt only when the Seed was
+	// changed.
+	cache atomic.Value
+	// Seed holds the seed value for the noise.
+	Seed int64
+}
+
+// state is the permutation table and the seed used to build it.
+type state struct {
+	// perm is the permutation of 0 to 255 repeated twice to avoid the overflow
+	// of the index.
+	perm [512]int
+	seed int64
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 Simplex noise value for the given coordinates.
+// It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 Simplex noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 Simplex noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.Eval1D64(float64(x)))
+}
+
+// Eval2D32 returns a float32 Simplex noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.Eval2D64(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 Simplex noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.Eval3D64(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 Simplex noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.Eval4D64(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 Simplex noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return clamp(scale1D * n.state().noise1(x))
+}
+
+// Eval2D64 returns a float64 Simplex noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return clamp(scale2D * n.state().noise2(x, y))
+}
+
+// Eval3D64 returns a float64 Simplex noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return clamp(scale3D * n.state().noise3(x, y, z))
+}
+
+// Eval4D64 returns a float64 Simplex noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return clamp(scale4D * n.state().noise4(x, y, z, w))
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in Simplex type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in Simplex type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// state returns the cached permutation table. It rebuilds the table only if it
+// has not been built yet or the Seed was changed.
+func (n *Generator) state() *state {
+	if c, ok := n.cache.Load().(*state); ok && c.seed == n.Seed {
+		return c
+	}
+
+	c := newState(n.Seed)
+
+	n.cache.Store(c)
+
+	return c
+}
+
+// noise1 returns the unscaled 1-dimensional Simplex noise value.
+func (s *state) noise1(x float64) float64 {
+	i0 := math.Floor(x)
+	x0 := x - i0
+	x1 := x0 - 1
+	ii := int(i0) & 255
+
+	return corner(1-x0*x0, grad1(s.perm[ii], x0)) +
+		corner(1-x1*x1, grad1(s.perm[ii+1], x1))
+}
+
+// noise2 returns the unscaled 2-dimensional Simplex noise value.
+func (s *state) noise2(x, y float64) float64 {
+	// Skew the input space to determine which simplex cell we are in.
+	skew := (x + y) * f2
+	i, j := math.Floor(x+skew), math.Floor(y+skew)
+
+	// Unskew the cell origin back to (x, y) space.
+	unskew := (i + j) * g2
+	x0, y0 := x-(i-unskew), y-(j-unskew)
+
+	// Determine which of the two triangles we are in.
+	i1, j1 := 0, 1
+	if x0 > y0 {
+		i1, j1 = 1, 0
+	}
+
+	x1, y1 := x0-float64(i1)+g2, y0-float64(j1)+g2
+	x2, y2 := x0-1+2*g2, y0-1+2*g2
+
+	p := &s.perm
+	ii, jj := int(i)&255, int(j)&255
+
+	return corner(0.5-x0*x0-y0*y0, grad2(p[ii+p[jj]], x0, y0)) +
+		corner(0.5-x1*x1-y1*y1, grad2(p[ii+i1+p[jj+j1]], x1, y1)) +
+		corner(0.5-x2*x2-y2*y2, grad2(p[ii+1+p[jj+1]], x2, y2))
+}
+
+// noise3 returns the unscaled 3-dimensional Simplex noise value.
+func (s *state) noise3(x, y, z float64) float64 {
+	skew := (x + y + z) * f3
+	i, j, k := math.Floor(x+skew), math.Floor(y+skew), math.Floor(z+skew)
+
+	unskew := (i + j + k) * g3
+	x0, y0, z0 := x-(i-unskew), y-(j-unskew), z-(k-unskew)
+
+	// Determine which of the six tetrahedra we are in by the rank of the
+	// coordinates.
+	rank := rankOf(x0, y0, z0)
+
+	i1, j1, k1 := bit(rank[0] >= 2), bit(rank[1] >= 2), bit(rank[2] >= 2)
+	i2, j2, k2 := bit(rank[0] >= 1), bit(rank[1] >= 1), bit(rank[2] >= 1)
+
+	x1, y1, z1 := x0-float64(i1)+g3, y0-float64(j1)+g3, z0-float64(k1)+g3
+	x2, y2, z2 := x0-float64(i2)+2*g3, y0-float64(j2)+2*g3, z0-float64(k2)+2*g3
+	x3, y3, z3 := x0-1+3*g3, y0-1+3*g3, z0-1+3*g3
+
+	p := &s.perm
+	ii, jj, kk := int(i)&255, int(j)&255, int(k)&255
+
+	return corner(0.6-x0*x0-y0*y0-z0*z0, grad3(p[ii+p[jj+p[kk]]], x0, y0, z0)) +
+		corner(0.6-x1*x1-y1*y1-z1*z1, grad3(p[ii+i1+p[jj+j1+p[kk+k1]]], x1, y1, z1)) +
+		corner(0.6-x2*x2-y2*y2-z2*z2, grad3(p[ii+i2+p[jj+j2+p[kk+k2]]], x2, y2, z2)) +
+		corner(0.6-x3*x3-y3*y3-z3*z3, grad3(p[ii+1+p[jj+1+p[kk+1]]], x3, y3, z3))
+}
+
+// noise4 returns the unscaled 4-dimensional Simplex noise value.
+func (s *state) noise4(x, y, z, w float64) float64 {
+	skew := (x + y + z + w) * f4
+	i, j, k, l := math.Floor(x+skew), math.Floor(y+skew), math.Floor(z+skew), math.Floor(w+skew)
+
+	unskew := (i + j + k + l) * g4
+	x0, y0, z0, w0 := x-(i-unskew), y-(j-unskew), z-(k-unskew), w-(l-unskew)
+
+	// Determine which of the 24 simplices we are in by the rank of the
+	// coordinates.
+	rank := rankOf(x0, y0, z0, w0)
+
+	p := &s.perm
+	ii, jj, kk, ll := int(i)&255, int(j)&255, int(k)&255, int(l)&255
+	sum := corner(0.6-x0*x0-y0*y0-z0*z0-w0*w0, grad4(p[ii+p[jj+p[kk+p[ll]]]], x0, y0, z0, w0))
+
+	// The 2nd to 5th corners of the simplex.
+	for c := 1; c <= 4; c++ {
+		i1, j1, k1, l1 := bit(rank[0] >= 4-c), bit(rank[1] >= 4-c), bit(rank[2] >= 4-c), bit(rank[3] >= 4-c)
+		offset := float64(c) * g4
+
+		x1 := x0 - float64(i1) + offset
+		y1 := y0 - float64(j1) + offset
+		z1 := z0 - float64(k1) + offset
+		w1 := w0 - float64(l1) + offset
+
+		sum += corner(
+			0.6-x1*x1-y1*y1-z1*z1-w1*w1,
+			grad4(p[ii+i1+p[jj+j1+p[kk+k1+p[ll+l1]]]], x1, y1, z1, w1),
+		)
+	}
+
+	return sum
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// newState returns the permutation table shuffled by the seed.
+func newState(seed int64) *state {
+	s := &state{seed: seed}
+
+	//nolint:gosec // Use of weak random number generation is intended here.
+	for i, v := range rand.New(rand.NewSource(seed)).Perm(256) {
+		s.perm[i] = v
+		s.perm[i+256] = v
+	}
+
+	return s
+}
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Simplex supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// clamp limits the rounding error of v to the range of [-1, 1].
+func clamp(v float64) float64 {
+	return math.Max(-1, math.Min(1, v))
+}
+
+// corner returns the contribution of a simplex corner. t is the attenuation of
+// the distance from the corner and g is the gradient dot product.
+func corner(t, g float64) float64 {
+	if t < 0 {
+		return 0
+	}
+
+	t *= t
+
+	return t * t * g
+}
+
+// bit returns 1 if b is true, otherwise 0.
+func bit(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+// rankOf returns the rank of each coordinate, which is the number of the other
+// coordinates smaller than it. Ties are broken by the order of the arguments.
+func rankOf(coords ...float64) [4]int {
+	var rank [4]int
+
+	for a := 0; a < len(coords); a++ {
+		for b := a + 1; b < len(coords); b++ {
+			if coords[a] > coords[b] {
+				rank[a]++
+			} else {
+				rank[b]++
+			}
+		}
+	}
+
+	return rank
+}
+
+// grad1 returns the product of x and one of the 16 gradients from -8 to 8,
+// excluding 0, chosen by the hash.
+func grad1(hash int, x float64) float64 {
+	h := hash & 15
+	g := float64(1 + (h & 7))
+
+	if h&8 != 0 {
+		g = -g
+	}
+
+	return g * x
+}
+
+// grad2 returns the dot product of (x, y) and one of the 8 gradients chosen by
+// the hash.
+func grad2(hash int, x, y float64) float64 {
+	h := hash & 7
+
+	u, v := y, x
+	if h < 4 {
+		u, v = x, y
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+
+	if h&2 != 0 {
+		v = -v
+	}
+
+	return u + 2*v
+}
+
+// grad3 returns the dot product of (x, y, z) and one of the 12 gradients of the
+// edges of a cube chosen by the hash.
+func grad3(hash int, x, y, z float64) float64 {
+	h := hash & 15
+
+	u := y
+	if h < 8 {
+		u = x
+	}
+
+	v := z
+
+	switch {
+	case h < 4:
+		v = y
+	case h == 12 || h == 14:
+		v = x
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+
+	if h&2 != 0 {
+		v = -v
+	}
+
+	return u + v
+}
+
+// grad4 returns the dot product of (x, y, z, w) and one of the 32 gradients of
+// the edges of a tesseract chosen by the hash.
+func grad4(hash int, x, y, z, w float64) float64 {
+	h := hash & 31
+
+	u, v, t := y, z, w
+
+	if h < 24 {
+		u = x
+	}
+
+	if h < 16 {
+		v = y
+	}
+
+	if h < 8 {
+		t = z
+	}
+
+	if h&1 != 0 {
+		u = -u
+	}
+
+	if h&2 != 0 {
+		v = -v
+	}
+
+	if h&4 != 0 {
+		t = -t
+	}
+
+	return u + v + t
+}
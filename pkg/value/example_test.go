@@ -0,0 +1,47 @@
+package value_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/value"
+)
+
+func ExampleNew() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := value.New(seed)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f\n",
+			gen.Eval64(x/smoothness),
+			gen.Eval64(x/smoothness, 0.3),
+			gen.Eval64(x/smoothness, 0.3, 0.6),
+			gen.Eval64(x/smoothness, 0.3, 0.6, 0.9),
+		)
+	}
+
+	// Output:
+	// 0.8340;0.5075;0.2088;0.1640
+	// 0.8464;0.4376;0.2314;0.1833
+	// 0.8647;0.3344;0.2646;0.2117
+}
+
+func ExampleInterpolation() {
+	gen := value.New(100)
+
+	for _, interp := range []value.Interpolation{value.Linear, value.Cosine, value.Smoothstep, value.Cubic, value.Quintic} {
+		gen.Interpolation = interp
+
+		fmt.Printf("%0.4f\n", gen.Eval64(0.2))
+	}
+
+	// Output:
+	// 0.8621
+	// 0.8450
+	// 0.8464
+	// 0.8678
+	// 0.8389
+}
@@ -0,0 +1,331 @@
+/*
+Package value is a native implementation of lattice value noise which implements
+github.com/KEINOS/go-noise/noise interface.
+
+Each integer lattice point has a random value chosen by the seed, and the values
+between them are interpolated. It is cheaper than gradient noises such as Perlin
+noise but looks more blocky.
+
+It supports 1 to 4 dimensions. The noise values are in the range of [-1, 1].
+*/
+package value
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-noise/internal/hash"
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a seeded value noise instance with the smoothstep interpolation.
+func New(seed int64) *Generator {
+	return &Generator{
+		Seed:          seed,
+		Interpolation: Smoothstep,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Interpolation
+// ----------------------------------------------------------------------------
+
+// Interpolation is the method to interpolate the values of the lattice points.
+//
+// Linear, Cosine, Smoothstep and Quintic are the curves applied to the
+// fractional part of the coordinates before interpolating the corners of the
+// cell linearly, thus none of them overshoots the range of [-1, 1]. Cubic
+// interpolates the 4 neighboring lattice points along each axis instead.
+type Interpolation int
+
+const (
+	// Linear interpolation. It is the cheapest and the most blocky one.
+	Linear Interpolation = iota
+	// Cosine interpolation. (1 - cos(πt)) / 2.
+	Cosine
+	// Smoothstep interpolation by the Hermite curve 3t² - 2t³. Its first
+	// derivative is continuous.
+	Smoothstep
+	// Cubic interpolation by the Catmull-Rom spline of the 4 lattice points
+	// around the point along each axis, 4^n points in n dimensions. Its first
+	// derivative is continuous and it is the smoothest but the most expensive
+	// one. The spline may overshoot the lattice values, which happens at about
+	// 1% of the points, so the value is clamped to the range of [-1, 1].
+	Cubic
+	// Quintic interpolation by the curve 6t⁵ - 15t⁴ + 10t³. Its first and second
+	// derivatives are continuous.
+	Quintic
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for value noise. It is an implementation of
+// Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines. But the fields must not be changed
+// while evaluating.
+type Generator struct {
+	// Seed holds the seed value for the noise.
+	Seed int64
+	// Interpolation is the method to interpolate the values of the lattice
+	// points.
+	Interpolation Interpolation
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 value noise value for the given coordinates.
+// It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 value noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 value noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.Eval1D64(float64(x)))
+}
+
+// Eval2D32 returns a float32 value noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.Eval2D64(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 value noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.Eval3D64(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 value noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.Eval4D64(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 value noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return n.eval(1, [maxDim]float64{x})
+}
+
+// Eval2D64 returns a float64 value noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return n.eval(2, [maxDim]float64{x, y})
+}
+
+// Eval3D64 returns a float64 value noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return n.eval(3, [maxDim]float64{x, y, z})
+}
+
+// Eval4D64 returns a float64 value noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return n.eval(4, [maxDim]float64{x, y, z, w})
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in Value type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in Value type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// eval returns the noise value at the coordinates of the given number of
+// dimensions by interpolating the values of the 2^numDim corners of the cell.
+func (n *Generator) eval(numDim int, point [maxDim]float64) float64 {
+	if n.Interpolation == Cubic {
+		return n.evalCubic(numDim, point)
+	}
+
+	var (
+		base   [maxDim]int64
+		weight [maxDim]float64
+	)
+
+	for i := 0; i < numDim; i++ {
+		floor := math.Floor(point[i])
+		base[i] = int64(floor)
+		weight[i] = n.fade(point[i] - floor)
+	}
+
+	sum := 0.
+
+	for c := 0; c < 1<<numDim; c++ {
+		corner := base
+		w := 1.
+
+		for i := 0; i < numDim; i++ {
+			if c>>i&1 == 1 {
+				corner[i]++
+				w *= weight[i]
+			} else {
+				w *= 1 - weight[i]
+			}
+		}
+
+		sum += w * latticeValue(n.Seed, numDim, corner)
+	}
+
+	return sum
+}
+
+// evalCubic returns the noise value at the coordinates of the given number of
+// dimensions by the Catmull-Rom spline of the 4^numDim lattice points around
+// them, clamped to the range of [-1, 1].
+func (n *Generator) evalCubic(numDim int, point [maxDim]float64) float64 {
+	var (
+		base   [maxDim]int64
+		weight [maxDim][4]float64
+	)
+
+	for i := 0; i < numDim; i++ {
+		floor := math.Floor(point[i])
+		base[i] = int64(floor)
+		weight[i] = catmullRom(point[i] - floor)
+	}
+
+	sum := 0.
+
+	// Each of the numDim digits of c in base 4 is the offset from -1 to 2 of
+	// the axis.
+	for c := 0; c < 1<<(2*numDim); c++ {
+		corner := base
+		w := 1.
+
+		for i := 0; i < numDim; i++ {
+			j := c >> (2 * i) & 3
+			corner[i] += int64(j) - 1
+			w *= weight[i][j]
+		}
+
+		sum += w * latticeValue(n.Seed, numDim, corner)
+	}
+
+	return math.Max(-1, math.Min(1, sum))
+}
+
+// fade returns the interpolation weight of the fractional part t.
+func (n *Generator) fade(t float64) float64 {
+	switch n.Interpolation {
+	case Linear:
+		return t
+	case Cosine:
+		return (1 - math.Cos(t*math.Pi)) / 2
+	case Quintic:
+		return t * t * t * (t*(t*6-15) + 10)
+	case Smoothstep:
+	}
+
+	return t * t * (3 - 2*t)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Value supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// catmullRom returns the weights of the Catmull-Rom spline at the fractional
+// part t for the lattice points at -1, 0, 1 and 2.
+func catmullRom(t float64) [4]float64 {
+	t2 := t * t
+	t3 := t2 * t
+
+	return [4]float64{
+		(-t3 + 2*t2 - t) / 2,
+		(3*t3 - 5*t2 + 2) / 2,
+		(-3*t3 + 4*t2 + t) / 2,
+		(t3 - t2) / 2,
+	}
+}
+
+// latticeValue returns the random value in the range of [-1, 1] of the lattice
+// point chosen by the seed.
+func latticeValue(seed int64, numDim int, point [maxDim]int64) float64 {
+	return hash.ToRange(hash.Lattice(seed, numDim, point))
+}
@@ -0,0 +1,153 @@
+package value_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise/pkg/value"
+	"github.com/stretchr/testify/require"
+)
+
+var interpolations = []value.Interpolation{value.Linear, value.Cosine, value.Smoothstep, value.Cubic, value.Quintic}
+
+func TestGenerator_is_in_range(t *testing.T) {
+	for _, interp := range interpolations {
+		gen := value.New(100)
+		gen.Interpolation = interp
+
+		for d := 1; d <= 4; d++ {
+			minV, maxV := math.Inf(1), math.Inf(-1)
+
+			for i := 0; i < 20000; i++ {
+				f := float64(i)
+				v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+				minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+			}
+
+			require.GreaterOrEqual(t, minV, -1., "interpolation %v, %dD should be in the range of -1 to 1", interp, d)
+			require.LessOrEqual(t, maxV, 1., "interpolation %v, %dD should be in the range of -1 to 1", interp, d)
+			require.Less(t, minV, -0.5, "interpolation %v, %dD should be spread in the range", interp, d)
+			require.Greater(t, maxV, 0.5, "interpolation %v, %dD should be spread in the range", interp, d)
+		}
+	}
+}
+
+func TestGenerator_interpolates_lattice_values(t *testing.T) {
+	for _, interp := range interpolations {
+		gen := value.New(100)
+		gen.Interpolation = interp
+
+		// All the interpolations should pass the lattice points and, except
+		// Cubic, agree at the middle of them.
+		lattice0, lattice1 := gen.Eval64(2, 3), gen.Eval64(3, 3)
+
+		require.Equal(t, value.New(100).Eval64(2, 3), lattice0, "interpolation %v", interp)
+
+		if interp == value.Cubic {
+			continue
+		}
+
+		require.InDelta(t, (lattice0+lattice1)/2, gen.Eval64(2.5, 3), 1e-12, "interpolation %v", interp)
+	}
+}
+
+func TestGenerator_cubic(t *testing.T) {
+	gen := value.New(100)
+	gen.Interpolation = value.Cubic
+
+	// The Catmull-Rom spline at the middle weighs the 4 lattice points by
+	// (-1, 9, 9, -1) / 16.
+	for x := -3.; x < 3; x++ {
+		l0, l1, l2, l3 := gen.Eval64(x-1, 3), gen.Eval64(x, 3), gen.Eval64(x+1, 3), gen.Eval64(x+2, 3)
+		want := math.Max(-1, math.Min(1, (-l0+9*l1+9*l2-l3)/16))
+
+		require.InDelta(t, want, gen.Eval64(x+0.5, 3), 1e-12, "x: %v", x)
+	}
+}
+
+func TestGenerator_continuous(t *testing.T) {
+	const (
+		eps   = 1e-7
+		delta = 1e-5
+	)
+
+	for _, interp := range interpolations {
+		gen := value.New(100)
+		gen.Interpolation = interp
+
+		for i := 0; i < 1000; i++ {
+			f := float64(i) * 0.173
+
+			require.InDelta(t, gen.Eval64(f), gen.Eval64(f+eps), delta)
+			require.InDelta(t, gen.Eval64(f, -f), gen.Eval64(f+eps, -f), delta)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f), gen.Eval64(f, -f+eps, 2*f), delta)
+			require.InDelta(t, gen.Eval64(f, -f, 2*f, 3*f), gen.Eval64(f, -f, 2*f, 3*f+eps), delta)
+		}
+	}
+}
+
+func TestGenerator_seed(t *testing.T) {
+	gen := value.New(100)
+
+	before := gen.Eval64(0.3, 0.6, 0.9)
+
+	require.Equal(t, before, value.New(100).Eval64(0.3, 0.6, 0.9), "same seed should return the same value")
+
+	gen.Seed = 101
+
+	require.NotEqual(t, before, gen.Eval64(0.3, 0.6, 0.9), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := value.New(100)
+
+	require.InDelta(t, gen.Eval64(0.1), gen.Eval32(0.1), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2), gen.Eval32(0.1, 0.2), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3), gen.Eval32(0.1, 0.2, 0.3), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), gen.Eval32(0.1, 0.2, 0.3, 0.4), 1e-6)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := value.New(100)
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	gen := value.New(100)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+		_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+	}))
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := value.New(100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
@@ -0,0 +1,34 @@
+package warp_test
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/fractal"
+	"github.com/KEINOS/go-noise/pkg/warp"
+)
+
+func ExampleNew() {
+	const seed = 100
+
+	src, err := noise.New(noise.OpenSimplex, seed)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Two stages of warping by fBm as f(p + 4*h(p + 4*g(p))).
+	g := fractal.New(src, seed+1)
+	h := fractal.New(src, seed+2)
+
+	gen := warp.New(fractal.New(src, seed), 4, g, h)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f\n", gen.Eval64(x/10), gen.Eval64(x/10, 0.3))
+	}
+
+	// Output:
+	// 0.1606;0.1668
+	// -0.1893;-0.2377
+	// 0.2291;-0.4063
+}
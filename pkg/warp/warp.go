@@ -0,0 +1,330 @@
+/*
+Package warp is a wrapper of any noise generator which warps the input
+coordinates by the output of other noise generators, also known as domain
+warping. It implements github.com/KEINOS/go-noise/noise interface itself, so it
+can be used anywhere a noise generator is expected.
+
+With a single stage it evaluates f(p + k*g(p)), where f is the source, g is the
+displacement and k is the strength. With more stages, each displacement is
+evaluated at the point warped by the previous stage. For example, two stages of
+g and h evaluate f(p + k*h(p + k*g(p))). This is the technique described by
+Inigo Quilez and produces swirly and organic patterns.
+
+Each axis of the displacement is sampled from the same displacement generator
+at a shifted point, so the axes are not correlated.
+
+It supports 1 to 3 dimensions.
+*/
+package warp
+
+import (
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/internal/displace"
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 3
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a domain warping generator of src. Each of the displacements is
+// a stage with the given strength, applied in the order.
+func New(src noise.Generator, strength float64, displacements ...noise.Generator) *Generator {
+	stages := make([]Stage, len(displacements))
+
+	for i, d := range displacements {
+		stages[i] = Stage{
+			Displacement: d,
+			Strength:     strength,
+		}
+	}
+
+	return &Generator{
+		Source: src,
+		Stages: stages,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Stage
+// ----------------------------------------------------------------------------
+
+// Stage is a step of the domain warping.
+type Stage struct {
+	// Displacement is the noise generator to displace the coordinates. It must
+	// not be nil.
+	Displacement noise.Generator
+	// Strength is the multiplier of the displacement. 0 means no warping.
+	Strength float64
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds the source and the stages of the domain warping. It is an
+// implementation of Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines as long as the Source and the
+// displacements are. But the fields must not be changed while evaluating.
+type Generator struct {
+	// Source is the noise generator to evaluate at the warped coordinates. It
+	// must not be nil.
+	Source noise.Generator
+	// Stages are the steps of the warping applied in the order. No stage means
+	// the same as the Source.
+	Stages []Stage
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 warped noise value for the given coordinates. The
+// Source and the displacements are evaluated in float32, so they may support
+// float32 only.
+//
+// It supports up to 3 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 warped noise value for the given coordinates.
+//
+// It supports up to 3 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 warped noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	v, _ := n.eval(1, [maxDim]float64{float64(x)}, false, true)
+
+	return float32(v)
+}
+
+// Eval2D32 returns a float32 warped noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	v, _ := n.eval(2, [maxDim]float64{float64(x), float64(y)}, false, true)
+
+	return float32(v)
+}
+
+// Eval3D32 returns a float32 warped noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	v, _ := n.eval(3, [maxDim]float64{float64(x), float64(y), float64(z)}, false, true)
+
+	return float32(v)
+}
+
+// Eval1D64 returns a float64 warped noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	v, _ := n.eval(1, [maxDim]float64{x}, false, false)
+
+	return v
+}
+
+// Eval2D64 returns a float64 warped noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	v, _ := n.eval(2, [maxDim]float64{x, y}, false, false)
+
+	return v
+}
+
+// Eval3D64 returns a float64 warped noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	v, _ := n.eval(3, [maxDim]float64{x, y, z}, false, false)
+
+	return v
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 3, the Source or a
+// displacement is nil or they fail to evaluate strictly.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	for i, d := range dim {
+		p[i] = float64(d)
+	}
+
+	v, err := n.eval(len(dim), p, true, true)
+
+	return float32(v), err
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 3, the Source or a
+// displacement is nil or they fail to evaluate strictly.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	var p [maxDim]float64
+
+	copy(p[:], dim)
+
+	return n.eval(len(dim), p, true, false)
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the Source instead.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in Warp type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error. Set the function to the Source instead.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in Warp type")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Private)
+// ----------------------------------------------------------------------------
+
+// eval returns the value of the Source at the point p of numDim dimensions
+// warped by the stages. If strict is true, the generators are evaluated via
+// noise.Eval64E, or noise.Eval32E if single is true, and the first error is
+// returned. If single is true, the generators are evaluated in float32, so they
+// may support float32 only.
+func (n *Generator) eval(numDim int, p [maxDim]float64, strict, single bool) (float64, error) {
+	warped := p
+
+	for i, stage := range n.Stages {
+		if stage.Displacement == nil {
+			if strict {
+				return 0, errors.Errorf("the displacement generator of the stage %d is not set", i)
+			}
+
+			return 0, nil
+		}
+
+		var next [maxDim]float64
+
+		for axis := 0; axis < numDim; axis++ {
+			var q [maxDim]float64
+
+			for j := 0; j < numDim; j++ {
+				q[j] = warped[j] + displace.Offsets[axis][j]
+			}
+
+			d, err := sample(stage.Displacement, numDim, q, strict, single)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed to evaluate the displacement of the stage %d", i)
+			}
+
+			next[axis] = p[axis] + stage.Strength*d
+		}
+
+		warped = next
+	}
+
+	if n.Source == nil {
+		if strict {
+			return 0, errors.New("the source generator is not set")
+		}
+
+		return 0, nil
+	}
+
+	v, err := sample(n.Source, numDim, warped, strict, single)
+
+	return v, errors.Wrap(err, "failed to evaluate the source")
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. Warp supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// sample returns the value of gen at the point q of numDim dimensions. If single
+// is true, it is evaluated in float32.
+func sample(gen noise.Generator, numDim int, q [maxDim]float64, strict, single bool) (float64, error) {
+	if single {
+		return sample32(gen, numDim, q, strict)
+	}
+
+	if strict {
+		// Copy to keep q on the stack in the non-strict path.
+		dim := make([]float64, numDim)
+		copy(dim, q[:numDim])
+
+		return noise.Eval64E(gen, dim...)
+	}
+
+	switch numDim {
+	case 1:
+		return noise.Eval1D64(gen, q[0]), nil
+	case 2:
+		return noise.Eval2D64(gen, q[0], q[1]), nil
+	}
+
+	return noise.Eval3D64(gen, q[0], q[1], q[2]), nil
+}
+
+// sample32 returns the float32 value of gen at the point q of numDim dimensions
+// as float64.
+func sample32(gen noise.Generator, numDim int, q [maxDim]float64, strict bool) (float64, error) {
+	if strict {
+		dim := make([]float32, numDim)
+
+		for i := range dim {
+			dim[i] = float32(q[i])
+		}
+
+		v, err := noise.Eval32E(gen, dim...)
+
+		return float64(v), err
+	}
+
+	switch numDim {
+	case 1:
+		return float64(noise.Eval1D32(gen, float32(q[0]))), nil
+	case 2:
+		return float64(noise.Eval2D32(gen, float32(q[0]), float32(q[1]))), nil
+	}
+
+	return float64(noise.Eval3D32(gen, float32(q[0]), float32(q[1]), float32(q[2]))), nil
+}
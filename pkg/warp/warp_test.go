@@ -0,0 +1,218 @@
+package warp_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/KEINOS/go-noise/pkg/warp"
+	"github.com/stretchr/testify/require"
+)
+
+func newSource(t *testing.T, algo noise.Algo, seed int64) noise.Generator {
+	t.Helper()
+
+	src, err := noise.New(algo, seed)
+	require.NoError(t, err)
+
+	return src
+}
+
+func TestGenerator_without_warping_equals_to_source(t *testing.T) {
+	src := newSource(t, noise.OpenSimplex, 100)
+
+	for _, gen := range []*warp.Generator{
+		warp.New(src, 4),
+		warp.New(src, 0, newSource(t, noise.Perlin, 101)),
+	} {
+		require.Equal(t, src.Eval64(0.1), gen.Eval64(0.1))
+		require.Equal(t, src.Eval64(0.1, 0.2), gen.Eval64(0.1, 0.2))
+		require.Equal(t, src.Eval64(0.1, 0.2, 0.3), gen.Eval64(0.1, 0.2, 0.3))
+	}
+}
+
+func TestGenerator_single_stage(t *testing.T) {
+	const k = 4
+
+	src := newSource(t, noise.OpenSimplex, 100)
+	g := newSource(t, noise.OpenSimplex, 101)
+	gen := warp.New(src, k, g)
+
+	x, y := 0.1, 0.2
+
+	// f(p + k*g(p)) where each axis of g is sampled at the shifted point.
+	gx := g.Eval64(x, y)
+	gy := g.Eval64(x+5.2, y+1.3)
+
+	require.Equal(t, src.Eval64(x+k*gx, y+k*gy), gen.Eval64(x, y))
+}
+
+func TestGenerator_multi_stage(t *testing.T) {
+	const k = 4
+
+	src := newSource(t, noise.OpenSimplex, 100)
+	g := newSource(t, noise.OpenSimplex, 101)
+	h := newSource(t, noise.OpenSimplex, 102)
+	gen := warp.New(src, k, g, h)
+
+	x := 0.3
+
+	// f(p + k*h(p + k*g(p)))
+	q := x + k*g.Eval64(x)
+	r := x + k*h.Eval64(q)
+
+	require.Equal(t, src.Eval64(r), gen.Eval64(x))
+
+	// Stages can have different strength.
+	gen.Stages[1].Strength = 2
+
+	r = x + 2*h.Eval64(q)
+
+	require.Equal(t, src.Eval64(r), gen.Eval64(x))
+}
+
+func TestGenerator_is_in_range(t *testing.T) {
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4, newSource(t, noise.Perlin, 101))
+
+	for d := 1; d <= 3; d++ {
+		minV, maxV := math.Inf(1), math.Inf(-1)
+
+		for i := 0; i < 5000; i++ {
+			f := float64(i)
+			v := gen.Eval64([]float64{f * 0.037, f * 0.011, f * 0.023}[:d]...)
+
+			minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+		}
+
+		require.GreaterOrEqual(t, minV, -1., "%dD should be in the range of -1 to 1", d)
+		require.LessOrEqual(t, maxV, 1., "%dD should be in the range of -1 to 1", d)
+		require.Less(t, minV+0.5, maxV, "%dD should be spread in the range", d)
+	}
+}
+
+func TestGenerator_continuous(t *testing.T) {
+	const (
+		eps   = 1e-7
+		delta = 1e-4
+	)
+
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4,
+		newSource(t, noise.OpenSimplex, 101), newSource(t, noise.OpenSimplex, 102))
+
+	for i := 0; i < 1000; i++ {
+		f := float64(i) * 0.0173
+
+		require.InDelta(t, gen.Eval64(f), gen.Eval64(f+eps), delta)
+		require.InDelta(t, gen.Eval64(f, -f), gen.Eval64(f+eps, -f), delta)
+		require.InDelta(t, gen.Eval64(f, -f, 2*f), gen.Eval64(f, -f+eps, 2*f), delta)
+	}
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4, newSource(t, noise.OpenSimplex, 101))
+
+	require.InDelta(t, gen.Eval64(float64(float32(0.1))), gen.Eval32(0.1), 1e-6)
+	require.InDelta(t, gen.Eval64(float64(float32(0.1)), float64(float32(0.2))), gen.Eval32(0.1, 0.2), 1e-6)
+}
+
+func TestGenerator_float32_only_generators(t *testing.T) {
+	newCustom := func(f func(v float64) float64) (noise.Generator, noise.Generator) {
+		gen32 := newSource(t, noise.Custom, 100)
+		require.NoError(t, gen32.SetEval32(func(seed int64, dim ...float32) float32 {
+			return float32(f(float64(dim[0])))
+		}))
+
+		gen64 := newSource(t, noise.Custom, 100)
+		require.NoError(t, gen64.SetEval64(func(seed int64, dim ...float64) float64 {
+			return f(dim[0])
+		}))
+
+		return gen32, gen64
+	}
+
+	src32, src64 := newCustom(math.Sin)
+	g32, g64 := newCustom(math.Cos)
+
+	gen := warp.New(src32, 4, g32)
+
+	require.NotPanics(t, func() {
+		gen.Eval32(0.1)
+		gen.Eval32(0.1, 0.2)
+		gen.Eval32(0.1, 0.2, 0.3)
+	})
+
+	v, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v)
+	require.InDelta(t, warp.New(src64, 4, g64).Eval64(0.1, 0.2), gen.Eval32(0.1, 0.2), 1e-5)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4, newSource(t, noise.OpenSimplex, 101))
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+	require.Error(t, err, "more than 3 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), "more than 3 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4), "more than 3 dimensions should return 0")
+}
+
+func TestGenerator_EvalE_generator_error(t *testing.T) {
+	gen := warp.New(nil, 4, newSource(t, noise.OpenSimplex, 101))
+
+	_, err := gen.Eval64E(0.1)
+	require.Error(t, err, "nil source should be an error")
+	require.Zero(t, gen.Eval64(0.1), "nil source should return 0")
+
+	gen = warp.New(newSource(t, noise.OpenSimplex, 100), 4, nil)
+
+	_, err = gen.Eval64E(0.1)
+	require.Error(t, err, "nil displacement should be an error")
+	require.Zero(t, gen.Eval64(0.1), "nil displacement should return 0")
+
+	// Custom without the user-defined function.
+	gen = warp.New(newSource(t, noise.OpenSimplex, 100), 4, newSource(t, noise.Custom, 101))
+
+	_, err = gen.Eval32E(0.1)
+	require.Error(t, err, "the error of the displacement should be returned")
+	require.Contains(t, err.Error(), "stage 0")
+
+	gen = warp.New(newSource(t, noise.Custom, 100), 4)
+
+	_, err = gen.Eval32E(0.1)
+	require.Error(t, err, "the error of the source should be returned")
+	require.Contains(t, err.Error(), "source")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4, newSource(t, noise.OpenSimplex, 101))
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+	}))
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := warp.New(newSource(t, noise.OpenSimplex, 100), 4)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
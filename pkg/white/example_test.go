@@ -0,0 +1,24 @@
+package white_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/white"
+)
+
+func ExampleNew() {
+	gen := white.New(100)
+
+	for x := 0.; x < 3; x++ {
+		fmt.Printf("%0.4f;%0.4f\n", gen.Eval64(x), gen.Eval64(x, 1))
+	}
+
+	// The same coordinates always return the same value.
+	fmt.Printf("%0.4f\n", gen.Eval64(0))
+
+	// Output:
+	// -0.2026;0.2739
+	// -0.0480;-0.9196
+	// -0.7082;0.3984
+	// -0.2026
+}
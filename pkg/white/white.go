@@ -0,0 +1,201 @@
+/*
+Package white is a deterministic white noise generator which implements
+github.com/KEINOS/go-noise/noise interface.
+
+The noise value is a hash of the seed and the coordinates, uniformly distributed
+in the range of [-1, 1]. Unlike a random number generator, it has no internal
+state. Thus the same coordinates always return the same value regardless of the
+order of the evaluations or the concurrency.
+
+Every distinct coordinate returns an independent value, even if they are very
+close. Floor the coordinates before evaluating to get a blocky noise.
+
+It supports 1 to 4 dimensions.
+*/
+package white
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-noise/internal/hash"
+	"github.com/pkg/errors"
+)
+
+// maxDim is the maximum number of dimensions supported.
+const maxDim = 4
+
+// ----------------------------------------------------------------------------
+//  Constructor
+// ----------------------------------------------------------------------------
+
+// New returns a seeded white noise instance.
+func New(seed int64) *Generator {
+	return &Generator{
+		Seed: seed,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Generator
+// ----------------------------------------------------------------------------
+
+// Generator holds parameter values for white noise. It is an implementation of
+// Generator interface.
+//
+// It holds no internal state and it is safe to call the evaluation methods
+// concurrently from multiple goroutines.
+type Generator struct {
+	// Seed holds the seed value for the noise.
+	Seed int64
+}
+
+// ----------------------------------------------------------------------------
+//  Methods (Public)
+// ----------------------------------------------------------------------------
+
+// Eval32 returns a float32 white noise value for the given coordinates.
+// It is a conversion of float64 to float32 to support Eval32 interface.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval32(dim ...float32) float32 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D32(dim[0])
+	case 2:
+		return n.Eval2D32(dim[0], dim[1])
+	case 3:
+		return n.Eval3D32(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D32(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval64 returns a float64 white noise value for the given coordinates.
+//
+// It supports up to 4 dimensions and returns 0 for more than that.
+func (n *Generator) Eval64(dim ...float64) float64 {
+	switch len(dim) {
+	case 1:
+		return n.Eval1D64(dim[0])
+	case 2:
+		return n.Eval2D64(dim[0], dim[1])
+	case 3:
+		return n.Eval3D64(dim[0], dim[1], dim[2])
+	case 4:
+		return n.Eval4D64(dim[0], dim[1], dim[2], dim[3])
+	}
+
+	return 0
+}
+
+// Eval1D32 returns a float32 white noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval32(x) without the variadic argument.
+func (n *Generator) Eval1D32(x float32) float32 {
+	return float32(n.Eval1D64(float64(x)))
+}
+
+// Eval2D32 returns a float32 white noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval32(x, y) without the variadic argument.
+func (n *Generator) Eval2D32(x, y float32) float32 {
+	return float32(n.Eval2D64(float64(x), float64(y)))
+}
+
+// Eval3D32 returns a float32 white noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D32(x, y, z float32) float32 {
+	return float32(n.Eval3D64(float64(x), float64(y), float64(z)))
+}
+
+// Eval4D32 returns a float32 white noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval32(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D32(x, y, z, w float32) float32 {
+	return float32(n.Eval4D64(float64(x), float64(y), float64(z), float64(w)))
+}
+
+// Eval1D64 returns a float64 white noise value at the 1-dimensional
+// coordinates. It is equivalent to Eval64(x) without the variadic argument.
+func (n *Generator) Eval1D64(x float64) float64 {
+	return hash.ToRange(mixCoord(mixSeed(n.Seed), x))
+}
+
+// Eval2D64 returns a float64 white noise value at the 2-dimensional
+// coordinates. It is equivalent to Eval64(x, y) without the variadic argument.
+func (n *Generator) Eval2D64(x, y float64) float64 {
+	return hash.ToRange(mixCoord(mixCoord(mixSeed(n.Seed), x), y))
+}
+
+// Eval3D64 returns a float64 white noise value at the 3-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z) without the variadic argument.
+func (n *Generator) Eval3D64(x, y, z float64) float64 {
+	return hash.ToRange(mixCoord(mixCoord(mixCoord(mixSeed(n.Seed), x), y), z))
+}
+
+// Eval4D64 returns a float64 white noise value at the 4-dimensional
+// coordinates. It is equivalent to Eval64(x, y, z, w) without the variadic
+// argument.
+func (n *Generator) Eval4D64(x, y, z, w float64) float64 {
+	return hash.ToRange(mixCoord(mixCoord(mixCoord(mixCoord(mixSeed(n.Seed), x), y), z), w))
+}
+
+// Eval32E is the strict version of Eval32. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval32E(dim ...float32) (float32, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval32(dim...), nil
+}
+
+// Eval64E is the strict version of Eval64. It returns an error instead of 0 if
+// the number of the coordinates is not between 1 and 4.
+func (n *Generator) Eval64E(dim ...float64) (float64, error) {
+	if err := checkDim(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return n.Eval64(dim...), nil
+}
+
+// SetEval32 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval32(f func(seed int64, dim ...float32) float32) error {
+	return errors.New("float32 evaluation function is already set. You can not set custom function in White type")
+}
+
+// SetEval64 is an implementation of noise.Generator interface. It will always
+// return an error.
+func (n *Generator) SetEval64(f func(seed int64, dim ...float64) float64) error {
+	return errors.New("float64 evaluation function is already set. You can not set custom function in White type")
+}
+
+// ----------------------------------------------------------------------------
+//  Functions (Private)
+// ----------------------------------------------------------------------------
+
+// checkDim returns an error if the number of dimensions is not supported.
+func checkDim(numDim int) error {
+	if numDim < 1 || maxDim < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. White supports 1 to %d dimensions", numDim, maxDim)
+	}
+
+	return nil
+}
+
+// mixSeed returns the initial hash of the seed.
+func mixSeed(seed int64) uint64 {
+	return hash.Mix(uint64(seed) + hash.Primes[0])
+}
+
+// mixCoord returns the hash h combined with the bits of the coordinate. -0 is
+// treated as 0 so that they return the same value.
+func mixCoord(h uint64, coord float64) uint64 {
+	if coord == 0 {
+		coord = 0
+	}
+
+	return hash.Mix(h ^ math.Float64bits(coord)*hash.Primes[1])
+}
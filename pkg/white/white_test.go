@@ -0,0 +1,143 @@
+package white_test
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/KEINOS/go-noise/pkg/white"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_is_uniform(t *testing.T) {
+	const (
+		numSamples = 100000
+		numBins    = 10
+	)
+
+	gen := white.New(100)
+
+	for d := 1; d <= 4; d++ {
+		var bins [numBins]int
+
+		sum := 0.
+
+		for i := 0; i < numSamples; i++ {
+			f := float64(i)
+			v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+			require.GreaterOrEqual(t, v, -1., "%dD should be in the range of -1 to 1", d)
+			require.LessOrEqual(t, v, 1., "%dD should be in the range of -1 to 1", d)
+
+			bins[int(math.Min((v+1)/2*numBins, numBins-1))]++
+			sum += v
+		}
+
+		require.InDelta(t, 0, sum/numSamples, 0.01, "%dD mean should be close to 0", d)
+
+		for i, count := range bins {
+			require.InDelta(t, numSamples/numBins, count, numSamples/numBins*0.05,
+				"%dD bin %d should have about the same number of samples", d, i)
+		}
+	}
+}
+
+func TestGenerator_order_independent(t *testing.T) {
+	gen := white.New(100)
+
+	forward := make([]float64, 100)
+	for i := range forward {
+		forward[i] = gen.Eval64(float64(i), 3)
+	}
+
+	// Evaluate backward and concurrently.
+	backward := make([]float64, 100)
+
+	var wg sync.WaitGroup
+
+	for i := len(backward) - 1; i >= 0; i-- {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			backward[i] = gen.Eval64(float64(i), 3)
+		}(i)
+	}
+
+	wg.Wait()
+
+	require.Equal(t, forward, backward, "the values should not depend on the order of the evaluations")
+}
+
+func TestGenerator_distinct_coordinates(t *testing.T) {
+	gen := white.New(100)
+
+	require.NotEqual(t, gen.Eval64(1, 2), gen.Eval64(2, 1), "swapped coordinates should differ")
+	require.NotEqual(t, gen.Eval64(1), gen.Eval64(1, 0), "different dimensions should differ")
+	require.NotEqual(t, gen.Eval64(0.1), gen.Eval64(math.Nextafter(0.1, 1)), "close coordinates should differ")
+	require.Equal(t, gen.Eval64(0), gen.Eval64(math.Copysign(0, -1)), "-0 and 0 should be the same")
+}
+
+func TestGenerator_seed(t *testing.T) {
+	gen := white.New(100)
+
+	before := gen.Eval64(0.3, 0.6, 0.9)
+
+	require.Equal(t, before, white.New(100).Eval64(0.3, 0.6, 0.9), "same seed should return the same value")
+
+	gen.Seed = 101
+
+	require.NotEqual(t, before, gen.Eval64(0.3, 0.6, 0.9), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := white.New(100)
+
+	require.InDelta(t, gen.Eval64(float64(float32(0.1))), gen.Eval32(0.1), 1e-6)
+	require.InDelta(t, gen.Eval64(float64(float32(0.1)), float64(float32(0.2))), gen.Eval32(0.1, 0.2), 1e-6)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := white.New(100)
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	gen := white.New(100)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+		_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+	}))
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := white.New(100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
@@ -0,0 +1,46 @@
+package worley_test
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-noise/pkg/worley"
+)
+
+func ExampleNew() {
+	const (
+		seed       = 100
+		smoothness = 10
+	)
+
+	gen := worley.New(seed)
+
+	for x := 1.; x < 4; x++ {
+		fmt.Printf("%0.4f;%0.4f;%0.4f;%0.4f\n",
+			gen.Eval64(x/smoothness),
+			gen.Eval64(x/smoothness, 0.3),
+			gen.Eval64(x/smoothness, 0.3, 0.6),
+			gen.Eval64(x/smoothness, 0.3, 0.6, 0.9),
+		)
+	}
+
+	// Output:
+	// -0.2726;-0.8797;-0.0167;-0.2428
+	// -0.4788;-0.7073;-0.1796;-0.2370
+	// -0.6850;-0.5299;-0.3349;-0.1908
+}
+
+// F2MinusF1 with Manhattan distance draws the borders of diamond shaped cells.
+func ExampleReturnType() {
+	gen := worley.New(100)
+	gen.Distance = worley.Manhattan
+	gen.Return = worley.F2MinusF1
+
+	for x := 0.; x < 3; x++ {
+		fmt.Printf("%0.4f\n", gen.Eval64(x*0.7, 0.3))
+	}
+
+	// Output:
+	// -0.5049
+	// -0.5231
+	// -0.2220
+}
@@ -0,0 +1,186 @@
+package worley_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise/pkg/worley"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_is_in_range(t *testing.T) {
+	for _, distance := range []worley.Distance{worley.Euclidean, worley.Manhattan, worley.Chebyshev, worley.Minkowski} {
+		for _, ret := range []worley.ReturnType{worley.F1, worley.F2, worley.F2MinusF1, worley.CellValue} {
+			gen := worley.New(100)
+			gen.Distance = distance
+			gen.Return = ret
+
+			for d := 1; d <= 4; d++ {
+				minV, maxV := math.Inf(1), math.Inf(-1)
+
+				for i := 0; i < 2000; i++ {
+					f := float64(i)
+					v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+					minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+				}
+
+				require.GreaterOrEqual(t, minV, -1., "distance %v, return %v, %dD should be in the range", distance, ret, d)
+				require.LessOrEqual(t, maxV, 1., "distance %v, return %v, %dD should be in the range", distance, ret, d)
+				require.Less(t, minV, maxV, "distance %v, return %v, %dD should not be constant", distance, ret, d)
+			}
+		}
+	}
+}
+
+func TestGenerator_F1_is_zero_at_feature_point(t *testing.T) {
+	gen := worley.New(100)
+	gen.Jitter = 0
+
+	// With no jitter, the feature points are at the center of the cells.
+	require.Equal(t, -1., gen.Eval64(0.5, 0.5))
+	require.Equal(t, -1., gen.Eval64(-3.5, 2.5, 7.5))
+	require.Greater(t, gen.Eval64(0.9, 0.9), -1.)
+}
+
+func TestGenerator_F2_is_second_nearest(t *testing.T) {
+	gen := worley.New(100)
+	gen.Jitter = 0
+
+	// With no jitter, the feature points are at the center of the cells and the
+	// maximums are the theoretical ones: 0.5 of F1 and 1 of F2 in 1D.
+	for ret, want := range map[worley.ReturnType]float64{
+		worley.F1:        2*0.25/0.5 - 1,
+		worley.F2:        2*0.75/1 - 1,
+		worley.F2MinusF1: 2*(0.75-0.25)/1 - 1,
+	} {
+		gen.Return = ret
+
+		require.InDelta(t, want, gen.Eval64(0.75), 1e-12, "return %v", ret)
+	}
+}
+
+func TestGenerator_spread(t *testing.T) {
+	for _, distance := range []worley.Distance{worley.Euclidean, worley.Manhattan, worley.Chebyshev, worley.Minkowski} {
+		for _, ret := range []worley.ReturnType{worley.F1, worley.F2, worley.F2MinusF1} {
+			gen := worley.New(100)
+			gen.Distance = distance
+			gen.Return = ret
+
+			for d := 1; d <= 4; d++ {
+				const numSample = 20000
+
+				minV, maxV, sum := math.Inf(1), math.Inf(-1), 0.
+
+				for i := 0; i < numSample; i++ {
+					f := float64(i)
+					v := gen.Eval64([]float64{f * 0.37, f * 0.11, f * 0.23, f * 0.07}[:d]...)
+
+					minV, maxV = math.Min(minV, v), math.Max(maxV, v)
+					sum += v
+				}
+
+				msg := []interface{}{"distance %v, return %v, %dD", distance, ret, d}
+
+				// The values should use most of the range, not only the bounds.
+				require.Greater(t, maxV, 0.7, msg...)
+				require.Greater(t, maxV-minV, 1.4, msg...)
+				require.Greater(t, sum/numSample, -0.8, msg...)
+				require.Less(t, sum/numSample, 0.5, msg...)
+			}
+		}
+	}
+}
+
+func TestGenerator_CellValue_is_constant_in_cell(t *testing.T) {
+	gen := worley.New(100)
+	gen.Return = worley.CellValue
+	gen.Jitter = 0
+
+	// With no jitter, the cells of the nearest feature points are the unit cells.
+	require.Equal(t, gen.Eval64(0.1, 0.1), gen.Eval64(0.9, 0.8))
+	require.NotEqual(t, gen.Eval64(0.1, 0.1), gen.Eval64(1.1, 0.1))
+}
+
+func TestGenerator_MinkowskiP(t *testing.T) {
+	euclidean, minkowski := worley.New(100), worley.New(100)
+	minkowski.Distance = worley.Minkowski
+
+	minkowski.MinkowskiP = 2
+	require.InDelta(t, euclidean.Eval64(0.3, 0.6), minkowski.Eval64(0.3, 0.6), 1e-12)
+
+	minkowski.MinkowskiP = 0
+	require.Equal(t, euclidean.Eval64(0.3, 0.6), minkowski.Eval64(0.3, 0.6),
+		"non-positive P should be treated as Euclidean")
+
+	manhattan := worley.New(100)
+	manhattan.Distance = worley.Manhattan
+
+	minkowski.MinkowskiP = 1
+	require.InDelta(t, manhattan.Eval64(0.3, 0.6), minkowski.Eval64(0.3, 0.6), 1e-12)
+}
+
+func TestGenerator_seed(t *testing.T) {
+	gen := worley.New(100)
+
+	before := gen.Eval64(0.3, 0.6, 0.9)
+
+	require.Equal(t, before, worley.New(100).Eval64(0.3, 0.6, 0.9), "same seed should return the same value")
+
+	gen.Seed = 101
+
+	require.NotEqual(t, before, gen.Eval64(0.3, 0.6, 0.9), "changing the Seed should change the noise value")
+}
+
+func TestGenerator_float32(t *testing.T) {
+	gen := worley.New(100)
+
+	require.InDelta(t, gen.Eval64(0.1), gen.Eval32(0.1), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2), gen.Eval32(0.1, 0.2), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3), gen.Eval32(0.1, 0.2, 0.3), 1e-6)
+	require.InDelta(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), gen.Eval32(0.1, 0.2, 0.3, 0.4), 1e-6)
+}
+
+func TestGenerator_EvalE(t *testing.T) {
+	gen := worley.New(100)
+
+	v32, err := gen.Eval32E(0.1, 0.2)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2), v32)
+
+	v64, err := gen.Eval64E(0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = gen.Eval32E()
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = gen.Eval64E(0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than 4 dimensions should be an error")
+	require.Zero(t, gen.Eval64(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+	require.Zero(t, gen.Eval32(0.1, 0.2, 0.3, 0.4, 0.5), "more than 4 dimensions should return 0")
+}
+
+func TestGenerator_fixed_arity_zero_allocation(t *testing.T) {
+	gen := worley.New(100)
+
+	require.Zero(t, testing.AllocsPerRun(100, func() {
+		_ = gen.Eval1D64(0.1)
+		_ = gen.Eval2D64(0.1, 0.2)
+		_ = gen.Eval3D64(0.1, 0.2, 0.3)
+		_ = gen.Eval4D64(0.1, 0.2, 0.3, 0.4)
+		_ = gen.Eval1D32(0.1)
+		_ = gen.Eval2D32(0.1, 0.2)
+		_ = gen.Eval3D32(0.1, 0.2, 0.3)
+		_ = gen.Eval4D32(0.1, 0.2, 0.3, 0.4)
+	}))
+}
+
+func TestGenerator_SetEval(t *testing.T) {
+	gen := worley.New(100)
+
+	require.Error(t, gen.SetEval32(func(seed int64, dim ...float32) float32 { return 0 }))
+	require.Error(t, gen.SetEval64(func(seed int64, dim ...float64) float64 { return 0 }))
+}
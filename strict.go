@@ -0,0 +1,78 @@
+package noise
+
+import "github.com/pkg/errors"
+
+// MaxDimension is the maximum number of dimensions supported by the built-in
+// generators, except Custom.
+const MaxDimension = 4
+
+// ----------------------------------------------------------------------------
+//  Types
+// ----------------------------------------------------------------------------
+
+// StrictGenerator is an optional interface of Generator which reports the invalid
+// evaluation as an error instead of returning a silent 0.
+//
+// All the generators of New, the generators of the sub packages and the
+// wrappers, such as fractal, warp and module, implement this interface.
+// Use the Eval32E and Eval64E functions to evaluate strictly with any Generator.
+type StrictGenerator interface {
+	// Eval32E is the strict version of Generator.Eval32.
+	Eval32E(dim ...float32) (float32, error)
+	// Eval64E is the strict version of Generator.Eval64.
+	Eval64E(dim ...float64) (float64, error)
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// Eval32E returns a float32 noise value of gen at the given coordinates. Unlike
+// gen.Eval32, it returns an error if the number of dimensions is not supported
+// or, in case of Custom, the user-defined function is not set.
+//
+// If gen does not implement StrictGenerator, it returns an error if the number
+// of dimensions is not between 1 and MaxDimension.
+func Eval32E(gen Generator, dim ...float32) (float32, error) {
+	if strict, ok := gen.(StrictGenerator); ok {
+		return strict.Eval32E(dim...)
+	}
+
+	if err := checkDimension(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return gen.Eval32(dim...), nil
+}
+
+// Eval64E returns a float64 noise value of gen at the given coordinates. Unlike
+// gen.Eval64, it returns an error if the number of dimensions is not supported
+// or, in case of Custom, the user-defined function is not set.
+//
+// If gen does not implement StrictGenerator, it returns an error if the number
+// of dimensions is not between 1 and MaxDimension.
+func Eval64E(gen Generator, dim ...float64) (float64, error) {
+	if strict, ok := gen.(StrictGenerator); ok {
+		return strict.Eval64E(dim...)
+	}
+
+	if err := checkDimension(len(dim)); err != nil {
+		return 0, err
+	}
+
+	return gen.Eval64(dim...), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// checkDimension returns an error if the number of dimensions is not between 1
+// and MaxDimension.
+func checkDimension(numDim int) error {
+	if numDim < 1 || MaxDimension < numDim {
+		return errors.Errorf("unsupported number of dimensions: %d. it must be 1 to %d", numDim, MaxDimension)
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package noise_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalE_native(t *testing.T) {
+	for _, algo := range []noise.Algo{
+		noise.Perlin, noise.OpenSimplex, noise.Custom, noise.ImprovedPerlin, noise.Simplex,
+		noise.OpenSimplex2F, noise.OpenSimplex2S, noise.Worley, noise.Value, noise.White,
+	} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		_, ok := gen.(noise.StrictGenerator)
+		require.True(t, ok, "built-in generators should implement StrictGenerator")
+
+		_, err = noise.Eval32E(gen)
+		require.Error(t, err, "no coordinate should be an error. algo: %v", algo)
+
+		_, err = noise.Eval64E(gen)
+		require.Error(t, err, "no coordinate should be an error. algo: %v", algo)
+	}
+}
+
+func TestEvalE_fallback(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	fallback := evalOnly{Generator: gen}
+
+	v32, err := noise.Eval32E(fallback, 0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval32(0.1, 0.2, 0.3, 0.4), v32)
+
+	v64, err := noise.Eval64E(fallback, 0.1, 0.2, 0.3, 0.4)
+
+	require.NoError(t, err)
+	require.Equal(t, gen.Eval64(0.1, 0.2, 0.3, 0.4), v64)
+
+	_, err = noise.Eval32E(fallback)
+	require.Error(t, err, "no coordinate should be an error")
+
+	_, err = noise.Eval64E(fallback, 0.1, 0.2, 0.3, 0.4, 0.5)
+	require.Error(t, err, "more than MaxDimension should be an error")
+	require.Contains(t, err.Error(), "unsupported number of dimensions: 5")
+}
@@ -0,0 +1,128 @@
+"""
+Reference values of the improvedperlin package with the permutation table of Ken
+Perlin's Java implementation (https://mrl.nyu.edu/~perlin/noise/).
+
+The 3D noise is a transliteration of the Java implementation. The 1D, 2D and 4D
+noises extend it in the same manner with the gradients documented in the
+package. The values are raw, before the normalization.
+
+Run: python3 testdata/improvedperlin.py
+"""
+import math
+
+PERM = [
+    151, 160, 137, 91, 90, 15, 131, 13, 201, 95, 96, 53, 194, 233, 7, 225, 140, 36, 103, 30, 69, 142,
+    8, 99, 37, 240, 21, 10, 23, 190, 6, 148, 247, 120, 234, 75, 0, 26, 197, 62, 94, 252, 219, 203, 117,
+    35, 11, 32, 57, 177, 33, 88, 237, 149, 56, 87, 174, 20, 125, 136, 171, 168, 68, 175, 74, 165, 71,
+    134, 139, 48, 27, 166, 77, 146, 158, 231, 83, 111, 229, 122, 60, 211, 133, 230, 220, 105, 92, 41,
+    55, 46, 245, 40, 244, 102, 143, 54, 65, 25, 63, 161, 1, 216, 80, 73, 209, 76, 132, 187, 208, 89,
+    18, 169, 200, 196, 135, 130, 116, 188, 159, 86, 164, 100, 109, 198, 173, 186, 3, 64, 52, 217, 226,
+    250, 124, 123, 5, 202, 38, 147, 118, 126, 255, 82, 85, 212, 207, 206, 59, 227, 47, 16, 58, 17, 182,
+    189, 28, 42, 223, 183, 170, 213, 119, 248, 152, 2, 44, 154, 163, 70, 221, 153, 101, 155, 167, 43,
+    172, 9, 129, 22, 39, 253, 19, 98, 108, 110, 79, 113, 224, 232, 178, 185, 112, 104, 218, 246, 97,
+    228, 251, 34, 242, 193, 238, 210, 144, 12, 191, 179, 162, 241, 81, 51, 145, 235, 249, 14, 239, 107,
+    49, 192, 214, 31, 181, 199, 106, 157, 184, 84, 204, 176, 115, 121, 50, 45, 127, 4, 150, 254, 138,
+    236, 205, 93, 222, 114, 67, 29, 24, 72, 243, 141, 128, 195, 78, 66, 215, 61, 156, 180,
+]
+P = PERM + PERM
+
+
+def fade(t):
+    return t * t * t * (t * (t * 6 - 15) + 10)
+
+
+def lerp(t, a, b):
+    return a + t * (b - a)
+
+
+def grad1(h, x):
+    return x if h & 1 == 0 else -x
+
+
+# The 8 gradients of the edges and the diagonals of a square.
+GRAD2 = [(1, 1), (-1, 1), (1, -1), (-1, -1), (1, 0), (-1, 0), (0, 1), (0, -1)]
+
+
+def grad2(h, x, y):
+    gx, gy = GRAD2[h & 7]
+    return gx * x + gy * y
+
+
+def grad3(h, x, y, z):
+    # Same as the Java implementation.
+    h &= 15
+    u = x if h < 8 else y
+    v = y if h < 4 else (x if h in (12, 14) else z)
+    return (u if h & 1 == 0 else -u) + (v if h & 2 == 0 else -v)
+
+
+def grad4(h, x, y, z, w):
+    # One of the 32 edges of a tesseract. The upper 2 bits drop an axis and the
+    # lower 3 bits are the signs of the rest.
+    h &= 31
+    rest = [x, y, z, w]
+    del rest[h >> 3]
+    return sum(v if h & (1 << i) == 0 else -v for i, v in enumerate(rest))
+
+
+def noise1(x):
+    xi = math.floor(x) & 255
+    x -= math.floor(x)
+    return lerp(fade(x), grad1(P[xi], x), grad1(P[xi + 1], x - 1))
+
+
+def noise2(x, y):
+    xi, yi = math.floor(x) & 255, math.floor(y) & 255
+    x, y = x - math.floor(x), y - math.floor(y)
+    u, v = fade(x), fade(y)
+    a, b = P[xi] + yi, P[xi + 1] + yi
+    return lerp(v,
+                lerp(u, grad2(P[a], x, y), grad2(P[b], x - 1, y)),
+                lerp(u, grad2(P[a + 1], x, y - 1), grad2(P[b + 1], x - 1, y - 1)))
+
+
+def noise3(x, y, z):
+    # Transliteration of ImprovedNoise.noise of the Java implementation.
+    X, Y, Z = math.floor(x) & 255, math.floor(y) & 255, math.floor(z) & 255
+    x, y, z = x - math.floor(x), y - math.floor(y), z - math.floor(z)
+    u, v, w = fade(x), fade(y), fade(z)
+    A = P[X] + Y
+    AA, AB = P[A] + Z, P[A + 1] + Z
+    B = P[X + 1] + Y
+    BA, BB = P[B] + Z, P[B + 1] + Z
+    return lerp(w, lerp(v, lerp(u, grad3(P[AA], x, y, z), grad3(P[BA], x - 1, y, z)),
+                        lerp(u, grad3(P[AB], x, y - 1, z), grad3(P[BB], x - 1, y - 1, z))),
+                lerp(v, lerp(u, grad3(P[AA + 1], x, y, z - 1), grad3(P[BA + 1], x - 1, y, z - 1)),
+                     lerp(u, grad3(P[AB + 1], x, y - 1, z - 1), grad3(P[BB + 1], x - 1, y - 1, z - 1))))
+
+
+def noise4(x, y, z, w):
+    pos = [x, y, z, w]
+    lat = [math.floor(v) & 255 for v in pos]
+    frac = [v - math.floor(v) for v in pos]
+
+    def corner(cx, cy, cz, cw):
+        h = P[P[P[P[lat[0] + cx] + lat[1] + cy] + lat[2] + cz] + lat[3] + cw]
+        return grad4(h, frac[0] - cx, frac[1] - cy, frac[2] - cz, frac[3] - cw)
+
+    def cube(cw):
+        def square(cz):
+            return lerp(fade(frac[1]),
+                        lerp(fade(frac[0]), corner(0, 0, cz, cw), corner(1, 0, cz, cw)),
+                        lerp(fade(frac[0]), corner(0, 1, cz, cw), corner(1, 1, cz, cw)))
+        return lerp(fade(frac[2]), square(0), square(1))
+
+    return lerp(fade(frac[3]), cube(0), cube(1))
+
+
+if __name__ == "__main__":
+    print("noise3(3.14, 42, 7) =", repr(noise3(3.14, 42, 7)))
+
+    for p in [(0.5,), (3.7,), (-12.3,)]:
+        print("noise1%s = %r" % (p, noise1(*p)))
+    for p in [(0.5, 0.5), (3.7, -1.2), (-12.3, 45.6)]:
+        print("noise2%s = %r" % (p, noise2(*p)))
+    for p in [(0.5, 0.5, 0.5), (3.7, -1.2, 8.9), (-12.3, 45.6, -78.9)]:
+        print("noise3%s = %r" % (p, noise3(*p)))
+    for p in [(0.5, 0.5, 0.5, 0.5), (3.7, -1.2, 8.9, 0.4), (-12.3, 45.6, -78.9, 101.1)]:
+        print("noise4%s = %r" % (p, noise4(*p)))
@@ -0,0 +1,189 @@
+"""
+Reference values of the opensimplex2 package.
+
+The noise is evaluated by brute force: every lattice vertex around the point is
+summed up if it is in the kernel radius. It does not use the fast paths nor the
+candidate tables of the package, but it shares the hashing and the gradient
+sets of it.
+
+The scaling factors of 2D and 3D are the normalizers of the reference
+implementation by KdotJPG (https://github.com/KdotJPG/OpenSimplex2). The ones of
+4D are the maximum absolute values of the raw noise with the 4D gradient set of
+the package, which TestScale finds.
+
+Run: python3 testdata/opensimplex2.py
+"""
+import math
+
+MASK64 = (1 << 64) - 1
+
+PRIME_X = 0x5205402B9270C86F
+PRIME_Y = 0x598CD327003817B5
+PRIME_Z = 0x5BCC226E9FA0BACB
+PRIME_W = 0x56CC5227E58F554B
+HASH_MULTIPLIER = 0x53A3F72DEEC546F5
+SEED_FLIP_3D = -0x52D547B2E96ED629
+
+SKEW_2D = 0.366025403784439
+UNSKEW_2D = -0.21132486540518713
+ROOT3OVER3 = 0.577350269189626
+FALLBACK_ROTATE_3D = 2.0 / 3.0
+SKEW_4D = -0.138196601125011
+UNSKEW_4D = 0.309016994374947
+SKEW_A4_STAR = 0.30901699437494742410
+UNSKEW_A4_STAR = -0.13819660112501051518
+
+NORMALIZER = {
+    (False, 2): 0.01001634121365712,
+    (False, 3): 0.07969837668935331,
+    (False, 4): 0.036730023916654926,
+    (True, 2): 0.05481866495625118,
+    (True, 3): 0.2781926117527186,
+    (True, 4): 0.18096421768559132,
+}
+
+R_SQUARED = {
+    (False, 2): 0.5, (False, 3): 0.6, (False, 4): 0.6,
+    (True, 2): 2.0 / 3.0, (True, 3): 0.75, (True, 4): 0.8,
+}
+
+FALLBACK, IMPROVE_XY, IMPROVE_XZ = 0, 1, 2
+
+
+def i64(v):
+    v &= MASK64
+    return v - (1 << 64) if v >= 1 << 63 else v
+
+
+def signs(length):
+    return [[-1 if bits & (1 << i) else 1 for i in range(length)] for bits in range(1 << length)]
+
+
+GRAD2 = [(math.cos(math.radians(7.5 + 15 * i)), math.sin(math.radians(7.5 + 15 * i))) for i in range(24)]
+
+GRAD3 = []
+A, B, C = 2.22474487139, 3.0862664687972017, 1.1721513422464978
+for s in signs(3):
+    GRAD3 += [(s[0] * A, s[1] * A, s[2]), (s[0] * A, s[1], s[2] * A), (s[0], s[1] * A, s[2] * A)]
+for s in signs(2):
+    GRAD3 += [(s[0] * B, s[1] * C, 0), (s[0] * C, s[1] * B, 0), (s[0] * B, 0, s[1] * C),
+              (s[0] * C, 0, s[1] * B), (0, s[0] * B, s[1] * C), (0, s[0] * C, s[1] * B)]
+
+GRAD4 = []
+for s in signs(3):
+    GRAD4 += [(0, s[0], s[1], s[2]), (s[0], 0, s[1], s[2]), (s[0], s[1], 0, s[2]), (s[0], s[1], s[2], 0)]
+
+
+def gradient(seed, vertex, primes, grads, exponent, shift):
+    h = seed
+    for v, p in zip(vertex, primes):
+        h ^= i64(v * p)
+    h = i64(h * HASH_MULTIPLIER)
+    h ^= h >> (64 - exponent + shift)
+    return grads[((h >> shift) & ((1 << exponent) - 1)) % len(grads)]
+
+
+def kernel_sum(seed, pos, skew, unskew, r_squared, primes, grads, exponent, shift, offsets):
+    dim = len(pos)
+    s = sum(pos) * skew
+    skewed = [p + s for p in pos]
+    base = [math.floor(v) for v in skewed]
+    frac = [v - b for v, b in zip(skewed, base)]
+    value = 0.0
+    for c in offsets(dim):
+        t = (sum(frac) - sum(c)) * unskew
+        d = [f - ci + t for f, ci in zip(frac, c)]
+        a = r_squared - sum(v * v for v in d)
+        if a <= 0:
+            continue
+        g = gradient(seed, [b + ci for b, ci in zip(base, c)], primes, grads, exponent, shift)
+        value += a ** 4 * sum(gi * di for gi, di in zip(g, d))
+    return value
+
+
+def cube(lo, hi):
+    def offsets(dim):
+        result = [[]]
+        for _ in range(dim):
+            result = [r + [i] for r in result for i in range(lo, hi + 1)]
+        return result
+    return offsets
+
+
+def rotate3(o, x, y, z):
+    if o == IMPROVE_XY:
+        xy = x + y
+        s2 = xy * UNSKEW_2D
+        zz = z * ROOT3OVER3
+        return x + s2 + zz, y + s2 + zz, xy * -ROOT3OVER3 + zz
+    if o == IMPROVE_XZ:
+        xz = x + z
+        s2 = xz * UNSKEW_2D
+        yy = y * ROOT3OVER3
+        return x + s2 + yy, xz * -ROOT3OVER3 + yy, z + s2 + yy
+    r = FALLBACK_ROTATE_3D * (x + y + z)
+    return r - x, r - y, r - z
+
+
+def rotate4(o, x, y, z, w):
+    # The coordinates skewed to the A4 lattice as the reference does, unskewed
+    # back to the rotated ones.
+    if o == IMPROVE_XY:
+        xy = x + y
+        s2 = xy * -0.21132486540518699998
+        zz = z * 0.28867513459481294226
+        ww = w * 0.2236067977499788
+        xs, ys, zs, ws = x + (zz + ww + s2), y + (zz + ww + s2), xy * -0.57735026918962599998 + (zz + ww), \
+            z * -0.866025403784439 + ww
+    elif o == IMPROVE_XZ:
+        xz = x + z
+        s2 = xz * -0.21132486540518699998
+        yy = y * 0.28867513459481294226
+        ww = w * 0.2236067977499788
+        xs, ys, zs, ws = x + (yy + ww + s2), xz * -0.57735026918962599998 + (yy + ww), z + (yy + ww + s2), \
+            y * -0.866025403784439 + ww
+    else:
+        s = (x + y + z + w) * SKEW_4D
+        xs, ys, zs, ws = x + s, y + s, z + s, w + s
+    s = (xs + ys + zs + ws) * UNSKEW_4D
+    return xs + s, ys + s, zs + s, ws + s
+
+
+def noise(smooth, orientation, seed, *pos):
+    if len(pos) == 1:
+        pos = (pos[0], 0.0)
+    dim = len(pos)
+    r_squared = R_SQUARED[(smooth, dim)]
+
+    if dim == 2:
+        raw = kernel_sum(seed, pos, SKEW_2D, UNSKEW_2D, r_squared, (PRIME_X, PRIME_Y), GRAD2, 7, 1, cube(-2, 3))
+    elif dim == 3:
+        # The BCC lattice as two cubic lattices offset by half a unit.
+        xr, yr, zr = rotate3(orientation, *pos)
+        primes = (PRIME_X, PRIME_Y, PRIME_Z)
+        raw = kernel_sum(seed, (xr, yr, zr), 0, 0, r_squared, primes, GRAD3, 8, 2, cube(-2, 3))
+        raw += kernel_sum(seed ^ SEED_FLIP_3D, (xr + 0.5, yr + 0.5, zr + 0.5), 0, 0, r_squared, primes, GRAD3, 8, 2,
+                          cube(-2, 3))
+    else:
+        raw = kernel_sum(seed, rotate4(orientation, *pos), SKEW_A4_STAR, UNSKEW_A4_STAR, r_squared,
+                         (PRIME_X, PRIME_Y, PRIME_Z, PRIME_W), GRAD4, 9, 2, cube(-2, 3))
+
+    return raw / NORMALIZER[(smooth, dim)]
+
+
+POINTS = [
+    (0.3,), (-12.7,),
+    (0.3, 0.6), (-12.7, 45.1),
+    (0.3, 0.6, 0.9), (-12.7, 45.1, -7.3),
+    (0.3, 0.6, 0.9, 1.2), (-12.7, 45.1, -7.3, 3.9),
+]
+
+if __name__ == "__main__":
+    for smooth in (False, True):
+        for orientation in (FALLBACK, IMPROVE_XY, IMPROVE_XZ):
+            for seed in (0, 100):
+                for p in POINTS:
+                    if orientation != FALLBACK and len(p) < 3:
+                        continue
+                    print("smooth=%s orientation=%d seed=%d %s = %r"
+                          % (smooth, orientation, seed, p, noise(smooth, orientation, seed, *p)))
@@ -0,0 +1,166 @@
+package noise
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Types
+// ----------------------------------------------------------------------------
+
+// TileGenerator is an optional interface of Generator which evaluates periodic
+// 2D noise natively, such as by wrapping the lattice.
+//
+// The generator of Perlin implements this interface. Use the EvalTile*
+// functions to evaluate seamless tiles with any Generator. They use the native
+// evaluation only for the integer periods, which fit the lattice.
+type TileGenerator interface {
+	// EvalTile2D32 returns a float32 noise value at (x, y) which repeats every
+	// width and height.
+	EvalTile2D32(x, y, width, height float32) float32
+	// EvalTile2D64 returns a float64 noise value at (x, y) which repeats every
+	// width and height.
+	EvalTile2D64(x, y, width, height float64) float64
+}
+
+// ----------------------------------------------------------------------------
+//  Functions
+// ----------------------------------------------------------------------------
+
+// EvalTile2D32 returns a float32 noise value of gen at (x, y) which repeats every
+// width along the x axis and every height along the y axis. In other words,
+// the value at (x+width, y) and (x, y+height) is the same as (x, y).
+//
+// If gen implements TileGenerator and both width and height are integers, it
+// uses the native periodic evaluation. Otherwise it maps the tile onto a torus in
+// 4 dimensions, so gen must support 4D. It returns 0 if width or height is not
+// positive.
+func EvalTile2D32(gen Generator, x, y, width, height float32) float32 {
+	if native, ok := gen.(TileGenerator); ok && isInteger(float64(width)) && isInteger(float64(height)) {
+		return native.EvalTile2D32(x, y, width, height)
+	}
+
+	return float32(evalTorus(gen, float64(x), float64(y), float64(width), float64(height)))
+}
+
+// EvalTile2D64 returns a float64 noise value of gen at (x, y) which repeats every
+// width along the x axis and every height along the y axis. In other words,
+// the value at (x+width, y) and (x, y+height) is the same as (x, y).
+//
+// If gen implements TileGenerator and both width and height are integers, it
+// uses the native periodic evaluation. Otherwise it maps the tile onto a torus in
+// 4 dimensions, so gen must support 4D. It returns 0 if width or height is not
+// positive.
+func EvalTile2D64(gen Generator, x, y, width, height float64) float64 {
+	if native, ok := gen.(TileGenerator); ok && isInteger(width) && isInteger(height) {
+		return native.EvalTile2D64(x, y, width, height)
+	}
+
+	return evalTorus(gen, x, y, width, height)
+}
+
+// EvalTileGrid2D32 fills dst with the float32 noise values of gen at the sample
+// points of the 2D grid in row-major order. The values are periodic over the
+// grid, so the image of dst can be tiled seamlessly.
+//
+// The period is Width*StepX along the x axis and Height*StepY along the y axis.
+// See EvalTile2D32 for the details.
+func EvalTileGrid2D32(gen Generator, dst []float32, g *Grid) error {
+	if err := validateTile(g, len(dst)); err != nil {
+		return err
+	}
+
+	width, height := float32(float64(g.Width)*g.StepX), float32(float64(g.Height)*g.StepY)
+
+	for y := 0; y < g.Height; y++ {
+		yy := float32(g.AtY(y))
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = EvalTile2D32(gen, float32(g.AtX(x)), yy, width, height)
+		}
+	}
+
+	return nil
+}
+
+// EvalTileGrid2D64 fills dst with the float64 noise values of gen at the sample
+// points of the 2D grid in row-major order. The values are periodic over the
+// grid, so the image of dst can be tiled seamlessly.
+//
+// The period is Width*StepX along the x axis and Height*StepY along the y axis.
+// See EvalTile2D64 for the details.
+func EvalTileGrid2D64(gen Generator, dst []float64, g *Grid) error {
+	if err := validateTile(g, len(dst)); err != nil {
+		return err
+	}
+
+	width, height := float64(g.Width)*g.StepX, float64(g.Height)*g.StepY
+
+	for y := 0; y < g.Height; y++ {
+		yy := g.AtY(y)
+		row := dst[y*g.Width : (y+1)*g.Width]
+
+		for x := range row {
+			row[x] = EvalTile2D64(gen, g.AtX(x), yy, width, height)
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// evalTorus returns the value of gen on the torus in 4 dimensions which the tile
+// of width and height is mapped onto. The radii keep the scale of the features
+// the same as the plain 2D noise.
+func evalTorus(gen Generator, x, y, width, height float64) float64 {
+	if width <= 0 || height <= 0 {
+		return 0
+	}
+
+	sinX, cosX := math.Sincos(2 * math.Pi * wrap(x, width) / width)
+	sinY, cosY := math.Sincos(2 * math.Pi * wrap(y, height) / height)
+
+	radiusX, radiusY := width/(2*math.Pi), height/(2*math.Pi)
+
+	return Eval4D64(gen, radiusX*cosX, radiusX*sinX, radiusY*cosY, radiusY*sinY)
+}
+
+// isInteger returns true if v is an integer.
+func isInteger(v float64) bool {
+	return v == math.Trunc(v)
+}
+
+// wrap returns v wrapped into the range of [0, period).
+func wrap(v, period float64) float64 {
+	v = math.Mod(v, period)
+	if v < 0 {
+		v += period
+	}
+
+	if v >= period {
+		// v was a tiny negative value and rounded up to the period.
+		return 0
+	}
+
+	return v
+}
+
+// validateTile returns an error if dst is too short for the grid or the period
+// of the grid is not positive.
+func validateTile(g *Grid, lenDst int) error {
+	if err := g.Validate2D(lenDst); err != nil {
+		return errors.Wrap(err, "invalid grid")
+	}
+
+	if g.StepX <= 0 || g.StepY <= 0 {
+		return errors.Errorf("invalid grid: the steps must be positive to tile. StepX: %v, StepY: %v", g.StepX, g.StepY)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package noise_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-noise"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvalTile2D_edges_match(t *testing.T) {
+	const width, height = 5., 4.
+
+	// OpenSimplex uses the torus mapping and Perlin the native wrapping.
+	for _, algo := range []noise.Algo{noise.OpenSimplex, noise.Perlin, noise.Value} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		for i := 0.; i <= 40; i++ {
+			// Dyadic fractions keep the shifts by the period exact.
+			x, y := i/8, i/8
+
+			require.Equal(t, noise.EvalTile2D64(gen, 0, y, width, height), noise.EvalTile2D64(gen, width, y, width, height),
+				"algo %d: the left and the right edges should match at y=%v", algo, y)
+			require.Equal(t, noise.EvalTile2D64(gen, x, 0, width, height), noise.EvalTile2D64(gen, x, height, width, height),
+				"algo %d: the top and the bottom edges should match at x=%v", algo, x)
+			require.Equal(t, noise.EvalTile2D64(gen, x, y, width, height), noise.EvalTile2D64(gen, x+2*width, y-height, width, height),
+				"algo %d: it should repeat outside of the tile at (%v, %v)", algo, x, y)
+			require.Equal(t, noise.EvalTile2D32(gen, 0, float32(y), width, height), noise.EvalTile2D32(gen, width, float32(y), width, height),
+				"algo %d: the left and the right edges should match at y=%v", algo, y)
+		}
+
+		// The seam is continuous.
+		require.InDelta(t,
+			noise.EvalTile2D64(gen, 0, 1.5, width, height),
+			noise.EvalTile2D64(gen, width-1e-9, 1.5, width, height),
+			1e-6, "algo %d: the seam should be continuous", algo)
+	}
+}
+
+func TestEvalTileGrid2D_non_integer_period(t *testing.T) {
+	// The period is 2.56 x 1.6, which does not fit the lattice of Perlin.
+	g := &noise.Grid{StepX: 0.01, StepY: 0.1, Width: 256, Height: 16}
+
+	for _, algo := range []noise.Algo{noise.OpenSimplex, noise.Perlin} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		out := make([]float64, g.Len2D())
+		require.NoError(t, noise.EvalTileGrid2D64(gen, out, g))
+
+		at := func(x, y int) float64 {
+			return out[(y%g.Height)*g.Width+x%g.Width]
+		}
+
+		// The step across the seam is no larger than the ones inside the tile.
+		var maxStepX, maxStepY, seamX, seamY float64
+
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				stepX, stepY := math.Abs(at(x+1, y)-at(x, y)), math.Abs(at(x, y+1)-at(x, y))
+
+				if x == g.Width-1 {
+					seamX = math.Max(seamX, stepX)
+				} else {
+					maxStepX = math.Max(maxStepX, stepX)
+				}
+
+				if y == g.Height-1 {
+					seamY = math.Max(seamY, stepY)
+				} else {
+					maxStepY = math.Max(maxStepY, stepY)
+				}
+			}
+		}
+
+		require.LessOrEqual(t, seamX, 1.5*maxStepX, "algo %d: the seam along the x axis should be continuous", algo)
+		require.LessOrEqual(t, seamY, 1.5*maxStepY, "algo %d: the seam along the y axis should be continuous", algo)
+	}
+}
+
+func TestEvalTile2D_uses_native(t *testing.T) {
+	gen, err := noise.New(noise.Perlin, 100)
+	require.NoError(t, err)
+
+	native, ok := gen.(noise.TileGenerator)
+	require.True(t, ok, "Perlin should implement TileGenerator")
+
+	require.Equal(t, native.EvalTile2D64(1.25, 2.5, 4, 4), noise.EvalTile2D64(gen, 1.25, 2.5, 4, 4))
+	require.Equal(t, native.EvalTile2D32(1.25, 2.5, 4, 4), noise.EvalTile2D32(gen, 1.25, 2.5, 4, 4))
+
+	// Without the native method, it falls back to the torus mapping.
+	fallback := evalOnly{Generator: gen}
+
+	require.NotEqual(t, noise.EvalTile2D64(gen, 1.25, 2.5, 4, 4), noise.EvalTile2D64(fallback, 1.25, 2.5, 4, 4))
+
+	// So does it for the non-integer periods.
+	require.Equal(t, noise.EvalTile2D64(fallback, 1.25, 2.5, 4.5, 4), noise.EvalTile2D64(gen, 1.25, 2.5, 4.5, 4))
+	require.Equal(t, noise.EvalTile2D32(fallback, 1.25, 2.5, 4, 3.5), noise.EvalTile2D32(gen, 1.25, 2.5, 4, 3.5))
+}
+
+func TestEvalTile2D_non_positive_period(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	require.Zero(t, noise.EvalTile2D64(gen, 0.5, 0.5, 0, 1))
+	require.Zero(t, noise.EvalTile2D64(gen, 0.5, 0.5, 1, -1))
+	require.Zero(t, noise.EvalTile2D32(gen, 0.5, 0.5, -1, 1))
+}
+
+func TestEvalTileGrid2D(t *testing.T) {
+	g := &noise.Grid{
+		X: -0.5, Y: 0.25,
+		StepX: 0.25, StepY: 0.5,
+		Width: 8, Height: 6,
+	}
+
+	for _, algo := range []noise.Algo{noise.OpenSimplex, noise.Perlin} {
+		gen, err := noise.New(algo, 100)
+		require.NoError(t, err)
+
+		out32 := make([]float32, g.Len2D())
+		out64 := make([]float64, g.Len2D())
+
+		require.NoError(t, noise.EvalTileGrid2D32(gen, out32, g))
+		require.NoError(t, noise.EvalTileGrid2D64(gen, out64, g))
+
+		width, height := float64(g.Width)*g.StepX, float64(g.Height)*g.StepY
+
+		for y := 0; y < g.Height; y++ {
+			for x := 0; x < g.Width; x++ {
+				i := y*g.Width + x
+
+				require.Equal(t, noise.EvalTile2D64(gen, g.AtX(x), g.AtY(y), width, height), out64[i])
+				require.InDelta(t, out64[i], float64(out32[i]), 1e-6)
+			}
+
+			// The next tile starts with the same value as the first column.
+			require.Equal(t, out64[y*g.Width], noise.EvalTile2D64(gen, g.AtX(g.Width), g.AtY(y), width, height))
+		}
+	}
+}
+
+func TestEvalTileGrid2D_invalid_grid(t *testing.T) {
+	gen, err := noise.New(noise.OpenSimplex, 100)
+	require.NoError(t, err)
+
+	g := &noise.Grid{StepX: 0.1, StepY: 0.1, Width: 2, Height: 2}
+
+	require.Error(t, noise.EvalTileGrid2D32(gen, nil, g))
+	require.Error(t, noise.EvalTileGrid2D64(gen, nil, g))
+
+	g.StepY = 0
+
+	require.Error(t, noise.EvalTileGrid2D32(gen, make([]float32, g.Len2D()), g))
+	require.Error(t, noise.EvalTileGrid2D64(gen, make([]float64, g.Len2D()), g))
+}